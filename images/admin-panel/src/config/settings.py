@@ -146,9 +146,16 @@ ELASTICSEARCH_SETTINGS = {  # noqa: WPS407
                 'language': 'russian',
             },
         },
+        'char_filter': {
+            'yo_to_ye': {
+                'type': 'mapping',
+                'mappings': ['ё => е', 'Ё => Е'],
+            },
+        },
         'analyzer': {
             'ru_en': {
                 'tokenizer': 'standard',
+                'char_filter': ['yo_to_ye'],
                 'filter': [
                     'lowercase',
                     'english_stop',
@@ -158,6 +165,11 @@ ELASTICSEARCH_SETTINGS = {  # noqa: WPS407
                     'russian_stemmer',
                 ],
             },
+            'suggest': {
+                'tokenizer': 'standard',
+                'char_filter': ['yo_to_ye'],
+                'filter': ['lowercase'],
+            },
         },
     },
 }
@@ -169,15 +181,31 @@ ELASTICSEARCH_INDICES = {  # noqa: WPS407
         'title': {
             'type': 'text',
             'analyzer': 'ru_en',
-            'fields': {'raw': {'type': 'keyword'}},
+            'fields': {
+                'raw': {'type': 'keyword'},
+                'suggest': {'type': 'text', 'analyzer': 'suggest'},
+            },
         },
         'description': {'type': 'text', 'analyzer': 'ru_en'},
         'release_date': {'type': 'date'},
         'type': {'type': 'keyword'},
         'age_rating': {'type': 'keyword'},
-        'directors_names': {'type': 'text', 'analyzer': 'ru_en'},
-        'actors_names': {'type': 'text', 'analyzer': 'ru_en'},
-        'writers_names': {'type': 'text', 'analyzer': 'ru_en'},
+        'access_type': {'type': 'keyword'},
+        'directors_names': {
+            'type': 'text',
+            'analyzer': 'ru_en',
+            'fields': {'suggest': {'type': 'text', 'analyzer': 'suggest'}},
+        },
+        'actors_names': {
+            'type': 'text',
+            'analyzer': 'ru_en',
+            'fields': {'suggest': {'type': 'text', 'analyzer': 'suggest'}},
+        },
+        'writers_names': {
+            'type': 'text',
+            'analyzer': 'ru_en',
+            'fields': {'suggest': {'type': 'text', 'analyzer': 'suggest'}},
+        },
         'actors': {
             'type': 'nested',
             'dynamic': 'strict',
@@ -202,14 +230,19 @@ ELASTICSEARCH_INDICES = {  # noqa: WPS407
                 'name': {'type': 'text', 'analyzer': 'ru_en'},
             },
         },
+        'modified': {'type': 'date'},
     },
     'persons': {
         'id': {'type': 'keyword'},
         'full_name': {
             'type': 'text',
             'analyzer': 'ru_en',
-            'fields': {'raw': {'type': 'keyword'}},
+            'fields': {
+                'raw': {'type': 'keyword'},
+                'suggest': {'type': 'text', 'analyzer': 'suggest'},
+            },
         },
+        'modified': {'type': 'date'},
     },
     'genres': {
         'id': {'type': 'keyword'},
@@ -219,6 +252,7 @@ ELASTICSEARCH_INDICES = {  # noqa: WPS407
             'fields': {'raw': {'type': 'keyword'}},
         },
         'description': {'type': 'text', 'analyzer': 'ru_en'},
+        'modified': {'type': 'date'},
     },
 }
 
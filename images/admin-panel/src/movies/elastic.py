@@ -2,7 +2,8 @@ import logging
 from typing import Any
 from uuid import UUID
 
-from elasticsearch import Elasticsearch
+from django.utils import timezone
+from elasticsearch import BadRequestError, Elasticsearch
 
 from config import settings
 from movies.models import Filmwork, Genre, Person
@@ -18,20 +19,36 @@ class ElasticsearchStartUpService:
         )
 
     def create_indices(self) -> None:
-        """Создаёт индексы если они не существуют"""
+        """Создаёт индексы если они не существуют и дополняет маппинги существующих"""
 
         for index_name, mapping in settings.ELASTICSEARCH_INDICES.items():
-            if not self.client.indices.exists(index=index_name):
-                try:
-                    body = {
-                        "settings": settings.ELASTICSEARCH_SETTINGS,
-                        "mappings": {"dynamic": "strict", "properties": mapping},
-                    }
-                    self.client.indices.create(index=index_name, body=body)
-                    logger.info(f"Создан индекс: {index_name}")
-                except Exception as e:
-                    logger.info(f"Индекс не создан: {index_name}")
-                    logger.error(f"Индекс не создан: {e}")
+            if self.client.indices.exists(index=index_name):
+                self.update_mapping(index_name, mapping)
+                continue
+            try:
+                body = {
+                    "settings": settings.ELASTICSEARCH_SETTINGS,
+                    "mappings": {"dynamic": "strict", "properties": mapping},
+                }
+                self.client.indices.create(index=index_name, body=body)
+                logger.info(f"Создан индекс: {index_name}")
+            except Exception as e:
+                logger.info(f"Индекс не создан: {index_name}")
+                logger.error(f"Индекс не создан: {e}")
+
+    def update_mapping(self, index_name: str, mapping: dict[str, Any]) -> None:
+        """
+        Добавляет в существующий индекс поля, которых в нём ещё нет.
+        Маппинг строгий, поэтому без этого запись документов с новыми полями
+        падает. Поля обновляются по одному: поле, которое нельзя изменить
+        на месте, не мешает добавить остальные и вступит в силу только после
+        переиндексации (команда reindex async-api).
+        """
+        for field, definition in mapping.items():
+            try:
+                self.client.indices.put_mapping(index=index_name, properties={field: definition})
+            except BadRequestError as e:
+                logger.warning(f"Поле {index_name}.{field} требует переиндексации: {e}")
 
 
 class ElasticsearchService:
@@ -61,7 +78,11 @@ class ElasticsearchService:
     def index_person(self, person: Person) -> bool:
         """Индексирует персону в Elasticsearch"""
         try:
-            doc = {"id": str(person.id), "full_name": person.full_name}
+            doc = {
+                "id": str(person.id),
+                "full_name": person.full_name,
+                "modified": timezone.now().isoformat(),
+            }
             self.client.index(index="persons", id=str(person.id), body=doc, refresh=True)
             logger.info(f"Персона индексирована: {person.full_name}")
             return True
@@ -72,7 +93,12 @@ class ElasticsearchService:
     def index_genre(self, genre: Genre) -> bool:
         """Индексирует жанр в Elasticsearch"""
         try:
-            doc = {"id": str(genre.id), "name": genre.name, "description": genre.description or ""}
+            doc = {
+                "id": str(genre.id),
+                "name": genre.name,
+                "description": genre.description or "",
+                "modified": timezone.now().isoformat(),
+            }
             self.client.index(index="genres", id=str(genre.id), body=doc, refresh=True)
             logger.info(f"Жанр индексирован: {genre.name}")
             return True
@@ -138,6 +164,7 @@ class ElasticsearchService:
             "release_date": filmwork.release_date.isoformat() if filmwork.release_date else None,
             "type": filmwork.type,
             "age_rating": filmwork.age_rating,
+            "access_type": filmwork.access_type,
             "genres": [genre.name for genre in genres],
             "actors": actors,
             "directors": directors,
@@ -145,6 +172,7 @@ class ElasticsearchService:
             "actors_names": [p["name"] for p in actors],
             "directors_names": [p["name"] for p in directors],
             "writers_names": [p["name"] for p in writers],
+            "modified": timezone.now().isoformat(),
         }
 
 
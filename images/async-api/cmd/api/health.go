@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/health"
+
+	"async-api/internal/rpc"
+	"async-api/pkg/breaker"
+)
+
+type healthzResponse struct {
+	Message string `json:"message"`
+}
+
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	response := healthzResponse{
+		Message: "ok",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+type readyzResponse struct {
+	Status        string `json:"status"`
+	Elasticsearch string `json:"elasticsearch"`
+	Redis         string `json:"redis"`
+}
+
+// readiness checks the dependencies the API needs to serve requests. It
+// backs both /readyz and the gRPC health service.
+type readiness struct {
+	esBreaker   *breaker.Breaker
+	redisClient *redis.Client
+}
+
+// readinessInterval is how often the gRPC health status is refreshed.
+const readinessInterval = 5 * time.Second
+
+func (c readiness) check(ctx context.Context) readyzResponse {
+	response := readyzResponse{
+		Status:        "ok",
+		Elasticsearch: c.esBreaker.State(),
+		Redis:         "ok",
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := c.redisClient.Ping(ctx).Err(); err != nil {
+		response.Redis = err.Error()
+	}
+
+	if c.esBreaker.IsOpen() {
+		response.Status = "unavailable"
+	}
+	return response
+}
+
+func readyzHandler(ready readiness) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response := ready.check(r.Context())
+		statusCode := http.StatusOK
+		if response.Status != "ok" {
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+// watchGRPCHealth keeps the gRPC health status in line with /readyz until ctx
+// is done.
+func watchGRPCHealth(ctx context.Context, ready readiness, healthServer *health.Server) {
+	ticker := time.NewTicker(readinessInterval)
+	defer ticker.Stop()
+	for {
+		rpc.SetServing(healthServer, ready.check(ctx).Status == "ok")
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,65 +1,130 @@
 package main
 
 import (
+	"async-api/internal/auth"
 	"async-api/internal/config"
+	"async-api/internal/domain/activity"
+	"async-api/internal/domain/bookmark"
 	"async-api/internal/domain/filmwork"
 	"async-api/internal/domain/genre"
 	"async-api/internal/domain/person"
+	"async-api/internal/domain/rating"
+	"async-api/internal/domain/stats"
+	"async-api/internal/export"
+	"async-api/internal/httpcache"
+	"async-api/internal/index"
+	"async-api/internal/metrics"
+	"async-api/internal/rpc"
+	"async-api/pkg/breaker"
+	"async-api/pkg/cache"
 	"async-api/pkg/database"
-	"encoding/json"
+	"context"
 	"log"
+	"net"
 	"net/http"
+	"os"
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 )
 
-type healthzResponse struct {
-	Message string `json:"message"`
-}
-
-func healthzHandler(w http.ResponseWriter, r *http.Request) {
-	response := healthzResponse{
-		Message: "ok",
+func main() {
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal("Failed to print config:", err)
+		}
+		return
 	}
-}
 
-func main() {
-	cfg, err := config.Load()
+	esBreaker := breaker.New("elasticsearch", breaker.Config{
+		Window:       cfg.Elastic.Breaker.Window,
+		MinRequests:  cfg.Elastic.Breaker.MinRequests,
+		FailureRatio: cfg.Elastic.Breaker.FailureRatio,
+		OpenTimeout:  cfg.Elastic.Breaker.OpenTimeout,
+	})
+	metrics.RegisterBreaker(esBreaker)
+
+	esClient, err := database.SetupElasticClient(*cfg, esBreaker)
 	if err != nil {
-		log.Fatal("Failed to load config:", err)
+		log.Fatal("Failed to setup Elasticsearch client:", err)
 	}
 
-	esClient, err := database.SetupElasticClient(*cfg)
+	redisClient, err := database.SetupRedisClient(*cfg)
 	if err != nil {
-		log.Fatal("Failed to setup Elasticsearch client:", err)
+		log.Fatal("Failed to setup Redis client:", err)
 	}
 
-	genreRepo := genre.NewGenreRepository(esClient)
+	responseCache := cache.New(redisClient, cfg.Cache.TTL, cfg.Cache.StaleTTL, metrics.CacheMetrics{})
+	go responseCache.Subscribe(context.Background(), cfg.Cache.InvalidationChannel)
+
+	genreRepo := genre.NewCachedGenreRepository(genre.NewGenreRepository(esClient, cfg.Elastic), responseCache)
 	genreService := genre.NewGenreService(genreRepo)
 	genreHandler := genre.NewGenreHandler(genreService)
 
-	personRepo := person.NewPersonRepository(esClient)
-	personService := person.NewPersonService(personRepo)
+	personRepo := person.NewCachedPersonRepository(person.NewPersonRepository(esClient, cfg.Elastic), responseCache)
+	personService := person.NewPersonService(personRepo, cfg.Path)
 	personHandler := person.NewPersonHandler(personService)
 
-	filmworkRepo := filmwork.NewFilmworkRepository(esClient)
-	filmworkService := filmwork.NewFilmworkService(filmworkRepo)
+	activityRepo := activity.NewCachedActivityRepository(activity.NewActivityRepository(redisClient, *cfg), responseCache)
+
+	var ratingRepo rating.Repository
+	var bookmarkRepo bookmark.Repository
+	if cfg.Mongo.Host != "" {
+		mongoClient, err := database.SetupMongoClient(*cfg)
+		if err != nil {
+			log.Fatal("Failed to setup Mongo client:", err)
+		}
+		ratingRepo = rating.NewCachedRatingRepository(rating.NewRatingRepository(mongoClient, *cfg), responseCache)
+		bookmarkRepo = bookmark.NewBookmarkRepository(mongoClient, *cfg)
+		collector := activity.NewCollector(activityRepo, ratingRepo, bookmarkRepo, redisClient, cfg.Activity.UGCInterval)
+		go collector.Run(context.Background())
+	}
+
+	filmworkRepo := filmwork.NewCachedFilmworkRepository(filmwork.NewFilmworkRepository(esClient, cfg.Elastic), genreRepo, responseCache)
+	filmworkService := filmwork.NewFilmworkService(filmworkRepo, personRepo, genreRepo, ratingRepo, bookmarkRepo, activityRepo)
 	filmworkHandler := filmwork.NewFilmworkHandler(filmworkService)
 
+	statsCache := cache.New(redisClient, cfg.Stats.CacheTTL, cfg.Cache.StaleTTL, metrics.CacheMetrics{})
+	statsRepo := stats.NewCachedStatsRepository(stats.NewStatsRepository(esClient, cfg.Elastic), statsCache)
+	statsHandler := stats.NewStatsHandler(stats.NewStatsService(statsRepo))
+
+	exportHandler := export.NewExportHandler(index.NewScanner(esClient, cfg.Export.PageSize, cfg.Export.KeepAlive, cfg.Elastic.Timeouts.Search), *cfg)
+
 	router := mux.NewRouter()
 
 	router.HandleFunc("/healthz", healthzHandler)
-	genreHandler.RegisterRoutes(router)
-	personHandler.RegisterRoutes(router)
-	filmworkHandler.RegisterRoutes(router)
+	ready := readiness{esBreaker: esBreaker, redisClient: redisClient}
+	router.HandleFunc("/readyz", readyzHandler(ready))
+	router.Handle("/metrics", metrics.Handler())
+	exportHandler.RegisterRoutes(router)
+
+	api := router.PathPrefix("/").Subrouter()
+	api.Use(httpcache.Middleware(cfg.HTTP.Cache))
+	genreHandler.RegisterRoutes(api)
+	personHandler.RegisterRoutes(api)
+	filmworkHandler.RegisterRoutes(api)
+	statsHandler.RegisterRoutes(api)
+
+	me := api.PathPrefix("/me").Subrouter()
+	me.Use(auth.RequireUser(cfg.Auth.JWTSecretKey))
+	filmworkHandler.RegisterUserRoutes(me)
+
+	grpcServer, healthServer := rpc.NewServer(rpc.NewCatalogServer(filmworkService, personService, genreService))
+	go watchGRPCHealth(context.Background(), ready, healthServer)
+	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
+	if err != nil {
+		log.Fatal("Failed to listen on gRPC port:", err)
+	}
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatal("gRPC server error:", err)
+		}
+	}()
 
 	corsHandler := handlers.CORS(
 		handlers.AllowedOrigins(cfg.HTTP.CORS.AllowOrigins),
@@ -67,5 +132,14 @@ func main() {
 		handlers.AllowedHeaders(cfg.HTTP.CORS.AllowHeaders),
 	)
 
-	log.Fatal(http.ListenAndServe(":"+cfg.HTTP.Port, corsHandler(router)))
+	server := &http.Server{
+		Addr:              ":" + cfg.HTTP.Port,
+		Handler:           corsHandler(router),
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+	}
+
+	log.Fatal(server.ListenAndServe())
 }
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"async-api/internal/config"
+	"async-api/internal/etl"
+	"async-api/internal/index"
+	"async-api/pkg/database"
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+
+	pool, err := database.SetupPostgresPool(*cfg)
+	if err != nil {
+		log.Fatal("Failed to setup Postgres pool:", err)
+	}
+	defer pool.Close()
+
+	esClient, err := database.SetupElasticClient(*cfg, nil)
+	if err != nil {
+		log.Fatal("Failed to setup Elasticsearch client:", err)
+	}
+
+	// Writes fail on fields that the strict mappings of indices created by an
+	// earlier version lack.
+	reindexer := index.NewReindexer(esClient)
+	for kind, alias := range map[string]string{
+		index.Movies:  cfg.Elastic.Indices.Movies,
+		index.Persons: cfg.Elastic.Indices.Persons,
+		index.Genres:  cfg.Elastic.Indices.Genres,
+	} {
+		stale, err := reindexer.UpdateMapping(context.Background(), kind, alias)
+		if err != nil {
+			log.Fatalf("Failed to update the mapping of %s: %v", alias, err)
+		}
+		if len(stale) > 0 {
+			log.Printf("%s: fields %v changed and take effect after reindex -migrate is run", alias, stale)
+		}
+	}
+
+	state, err := etl.LoadState(cfg.ETL.StateFile)
+	if err != nil {
+		log.Fatal("Failed to load ETL state:", err)
+	}
+
+	var notifier etl.Notifier
+	if cfg.ETL.PublishInvalidations {
+		redisClient, err := database.SetupRedisClient(*cfg)
+		if err != nil {
+			log.Fatal("Failed to setup Redis client:", err)
+		}
+		defer redisClient.Close()
+		notifier = etl.NewRedisNotifier(redisClient, cfg.Cache.InvalidationChannel)
+	}
+
+	extractor := etl.NewExtractor(pool)
+	pipeline := etl.NewPipeline(
+		extractor,
+		etl.NewLoader(esClient),
+		state,
+		cfg.Elastic.Indices,
+		cfg.ETL.BatchSize,
+		notifier,
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := extractor.InstallChangeLog(ctx); err != nil {
+		log.Fatal("Failed to install change log:", err)
+	}
+
+	if cfg.ETL.Listen {
+		listener := etl.NewListener(pool, pipeline, cfg.ETL.Debounce)
+		if err := listener.InstallTriggers(ctx); err != nil {
+			log.Fatal("Failed to install triggers:", err)
+		}
+		log.Println("Listening for Postgres changes")
+		if err := listener.Run(ctx); err != nil {
+			log.Fatal("CDC listener failed:", err)
+		}
+		return
+	}
+
+	for {
+		if err := pipeline.Run(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("ETL run failed: %v", err)
+			if cfg.ETL.Interval == 0 {
+				os.Exit(1)
+			}
+		}
+		if cfg.ETL.Interval == 0 {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(cfg.ETL.Interval):
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"async-api/internal/config"
+	"async-api/internal/index"
+	"async-api/pkg/database"
+	"context"
+	"flag"
+	"log"
+	"os"
+	"slices"
+)
+
+func main() {
+	flagSet := flag.NewFlagSet("reindex", flag.ContinueOnError)
+	kind := flagSet.String("index", "all", "index to rebuild: movies, persons, genres or all")
+	deleteOld := flagSet.Bool("delete-old", false, "delete the previous indices after the alias swap")
+	migrate := flagSet.Bool("migrate", false, "only update indices whose mapping or analysis settings are out of date")
+
+	cfg, err := config.LoadFlagSet(flagSet, os.Args[1:])
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+
+	kinds := index.Kinds
+	if *kind != "all" {
+		if !slices.Contains(index.Kinds, *kind) {
+			log.Fatalf("Unknown index '%s'", *kind)
+		}
+		kinds = []string{*kind}
+	}
+
+	esClient, err := database.SetupElasticClient(*cfg, nil)
+	if err != nil {
+		log.Fatal("Failed to setup Elasticsearch client:", err)
+	}
+
+	aliases := map[string]string{
+		index.Movies:  cfg.Elastic.Indices.Movies,
+		index.Persons: cfg.Elastic.Indices.Persons,
+		index.Genres:  cfg.Elastic.Indices.Genres,
+	}
+
+	reindexer := index.NewReindexer(esClient)
+	for _, k := range kinds {
+		var result *index.Result
+		if *migrate {
+			result, err = reindexer.Migrate(context.Background(), k, aliases[k], *deleteOld)
+		} else {
+			result, err = reindexer.Reindex(context.Background(), k, aliases[k], *deleteOld)
+		}
+		if err != nil {
+			log.Fatalf("Failed to reindex %s: %v", k, err)
+		}
+		if result == nil {
+			log.Printf("%s: up to date", aliases[k])
+			continue
+		}
+		log.Printf("%s: %d documents moved from %v to %s", result.Alias, result.Documents, result.OldIndices, result.NewIndex)
+	}
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"async-api/internal/http"
+)
+
+// RequireServiceToken lets through only requests that carry one of tokens as
+// a bearer token. All requests are rejected while tokens is empty.
+func RequireServiceToken(tokens []string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				response.SendErrorResponse(w, "Требуется сервисный токен", http.StatusUnauthorized)
+				return
+			}
+			if !validToken(tokens, token) {
+				response.SendErrorResponse(w, "Недействительный сервисный токен", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func validToken(tokens []string, token string) bool {
+	valid := false
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
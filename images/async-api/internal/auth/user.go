@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"async-api/internal/http"
+)
+
+type claimsKey struct{}
+
+// Claims are the JWT claims issued by the auth service. Access tokens carry
+// the audience of the user: AgeRating, the highest age rating the user may
+// watch, and Subscriber. Tokens issued without them grant the most
+// restrictive audience.
+type Claims struct {
+	Subject    string `json:"sub"`
+	ExpiresAt  int64  `json:"exp"`
+	Type       string `json:"type"`
+	AgeRating  string `json:"age_rating"`
+	Subscriber bool   `json:"subscriber"`
+}
+
+// RequireUser lets through only requests that carry an access token signed by
+// the auth service with secret (HS256) and stores its claims in the request
+// context. All requests are rejected while secret is empty.
+func RequireUser(secret string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok || secret == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				response.SendErrorResponse(w, "Требуется авторизация", http.StatusUnauthorized)
+				return
+			}
+			claims, err := ParseToken(token, secret, time.Now())
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				response.SendErrorResponse(w, "Недействительный токен: "+err.Error(), http.StatusUnauthorized)
+				return
+			}
+			ctx := context.WithValue(r.Context(), claimsKey{}, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// UserID returns the id of the user authenticated by RequireUser.
+func UserID(ctx context.Context) (string, bool) {
+	claims, ok := UserClaims(ctx)
+	if !ok {
+		return "", false
+	}
+	return claims.Subject, true
+}
+
+// UserClaims returns the verified claims of the user authenticated by
+// RequireUser.
+func UserClaims(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
+
+// ParseToken verifies an HS256 access token and returns its claims.
+func ParseToken(token string, secret string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Alg != "HS256" {
+		return nil, errors.New("unsupported signing algorithm")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("invalid signature")
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt == 0 {
+		return nil, errors.New("token has no expiry")
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, errors.New("token expired")
+	}
+	if claims.Type != "" && claims.Type != "access" {
+		return nil, errors.New("not an access token")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+	return &claims, nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.New("malformed token")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.New("malformed token")
+	}
+	return nil
+}
@@ -1,69 +1,419 @@
 package config
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	App     AppConfig
-	HTTP    HTTPConfig
-	Elastic ElasticConfig
-	Redis   RedisConfig
+	App      AppConfig      `yaml:"app"`
+	HTTP     HTTPConfig     `yaml:"http"`
+	GRPC     GRPCConfig     `yaml:"grpc"`
+	Elastic  ElasticConfig  `yaml:"elastic"`
+	Redis    RedisConfig    `yaml:"redis"`
+	Mongo    MongoConfig    `yaml:"mongo"`
+	Cache    CacheConfig    `yaml:"cache"`
+	Postgres PostgresConfig `yaml:"postgres"`
+	ETL      ETLConfig      `yaml:"etl"`
+	Export   ExportConfig   `yaml:"export"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Activity ActivityConfig `yaml:"activity"`
+	Path     PathConfig     `yaml:"path"`
+	Stats    StatsConfig    `yaml:"stats"`
+
+	File        string `yaml:"-"`
+	PrintConfig bool   `yaml:"-"`
 }
 
 type AppConfig struct {
-	Env string
+	Env string `yaml:"env"`
 }
 
 type HTTPConfig struct {
-	Port string
-	CORS CORSConfig
+	Port              string          `yaml:"port"`
+	ReadTimeout       time.Duration   `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration   `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration   `yaml:"write_timeout"`
+	IdleTimeout       time.Duration   `yaml:"idle_timeout"`
+	CORS              CORSConfig      `yaml:"cors"`
+	Cache             HTTPCacheConfig `yaml:"cache"`
+}
+
+// HTTPCacheConfig sets the Cache-Control policy of GET responses. Routes are
+// keyed by their path template, e.g. "/filmworks/{id}", and override Default.
+type HTTPCacheConfig struct {
+	Default CachePolicy            `yaml:"default"`
+	Routes  map[string]CachePolicy `yaml:"routes"`
+}
+
+type CachePolicy struct {
+	MaxAge               time.Duration `yaml:"max_age"`
+	StaleWhileRevalidate time.Duration `yaml:"stale_while_revalidate"`
+	StaleIfError         time.Duration `yaml:"stale_if_error"`
+	// Private marks responses that depend on the caller and must not be
+	// stored by shared caches.
+	Private bool `yaml:"private"`
+	NoStore bool `yaml:"no_store"`
+}
+
+// Policy returns the cache policy of the route with the given path template.
+func (c HTTPCacheConfig) Policy(route string) CachePolicy {
+	if policy, ok := c.Routes[route]; ok {
+		return policy
+	}
+	return c.Default
+}
+
+type GRPCConfig struct {
+	Port string `yaml:"port"`
 }
 
 type CORSConfig struct {
-	AllowOrigins []string
-	AllowMethods []string
-	AllowHeaders []string
+	AllowOrigins []string `yaml:"allow_origins"`
+	AllowMethods []string `yaml:"allow_methods"`
+	AllowHeaders []string `yaml:"allow_headers"`
 }
 
 type RedisConfig struct {
-	Host string
-	Port string
-	DB   string
+	Host string `yaml:"host"`
+	Port string `yaml:"port"`
+	DB   string `yaml:"db"`
+}
+
+// MongoConfig points at the UGC store holding audience ratings. Ratings are
+// left out of responses while Host is empty.
+type MongoConfig struct {
+	Host     string        `yaml:"host"`
+	Port     string        `yaml:"port"`
+	User     string        `yaml:"user"`
+	Password string        `yaml:"password"`
+	Database string        `yaml:"database"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+func (c MongoConfig) URI() string {
+	uri := url.URL{
+		Scheme: "mongodb",
+		Host:   c.Host + ":" + c.Port,
+	}
+	if c.User != "" {
+		uri.User = url.UserPassword(c.User, c.Password)
+	}
+	return uri.String()
+}
+
+type PostgresConfig struct {
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	DB       string `yaml:"db"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+}
+
+// Validate checks the Postgres settings. They are only required by the
+// commands that read from Postgres, so Config.Validate does not call it.
+func (c PostgresConfig) Validate() error {
+	var errs []error
+	if c.Host == "" {
+		errs = append(errs, fmt.Errorf("POSTGRES_HOST is required"))
+	}
+	if c.Port == "" {
+		errs = append(errs, fmt.Errorf("POSTGRES_PORT is required"))
+	}
+	if c.DB == "" {
+		errs = append(errs, fmt.Errorf("POSTGRES_DB is required"))
+	}
+	if c.User == "" {
+		errs = append(errs, fmt.Errorf("POSTGRES_USER is required"))
+	}
+	return errors.Join(errs...)
+}
+
+func (c PostgresConfig) DSN() string {
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.User, c.Password),
+		Host:   c.Host + ":" + c.Port,
+		Path:   c.DB,
+	}
+	return dsn.String()
+}
+
+type ETLConfig struct {
+	BatchSize int           `yaml:"batch_size"`
+	StateFile string        `yaml:"state_file"`
+	Interval  time.Duration `yaml:"interval"`
+	Listen    bool          `yaml:"listen"`
+	Debounce  time.Duration `yaml:"debounce"`
+	// PublishInvalidations posts the ids of loaded documents to the cache
+	// invalidation channel so that async-api evicts its cached copies.
+	PublishInvalidations bool `yaml:"publish_invalidations"`
+}
+
+type ExportConfig struct {
+	// Tokens are the service tokens accepted by the /export endpoints. Export
+	// is disabled while none is configured.
+	Tokens    []string      `yaml:"tokens"`
+	PageSize  int           `yaml:"page_size"`
+	KeepAlive time.Duration `yaml:"keep_alive"`
+}
+
+// AuthConfig verifies the access tokens issued by the auth service. The /me
+// endpoints reject every request while JWTSecretKey is empty.
+type AuthConfig struct {
+	JWTSecretKey string `yaml:"jwt_secret_key"`
+}
+
+// ActivityConfig drives the trending and popular rankings. Activity is summed
+// in Redis per Bucket and kept for Retention, which also bounds the trending
+// window. Older buckets count less, halving every half-life.
+type ActivityConfig struct {
+	Bucket           time.Duration `yaml:"bucket"`
+	Retention        time.Duration `yaml:"retention"`
+	TrendingWindow   time.Duration `yaml:"trending_window"`
+	TrendingHalfLife time.Duration `yaml:"trending_half_life"`
+	PopularHalfLife  time.Duration `yaml:"popular_half_life"`
+	ViewWeight       float64       `yaml:"view_weight"`
+	VoteWeight       float64       `yaml:"vote_weight"`
+	BookmarkWeight   float64       `yaml:"bookmark_weight"`
+	// UGCInterval is how often new votes and bookmarks are collected from
+	// the UGC store.
+	UGCInterval time.Duration `yaml:"ugc_interval"`
+}
+
+// PathConfig bounds the degrees-of-separation search of /persons/{a}/path/{b}.
+// MaxDepth is the longest chain, in shared filmworks, that is looked for;
+// BatchSize is the number of persons expanded per Elasticsearch query.
+type PathConfig struct {
+	MaxDepth  int           `yaml:"max_depth"`
+	Timeout   time.Duration `yaml:"timeout"`
+	BatchSize int           `yaml:"batch_size"`
+}
+
+// StatsConfig sets how long /stats is served from Redis. Statistics are not
+// invalidated on catalogue changes, so CacheTTL is kept short instead.
+type StatsConfig struct {
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+}
+
+type CacheConfig struct {
+	TTL      time.Duration `yaml:"ttl"`
+	StaleTTL time.Duration `yaml:"stale_ttl"`
+	// InvalidationChannel is the Redis pub/sub channel carrying entity change
+	// events.
+	InvalidationChannel string `yaml:"invalidation_channel"`
 }
 
 type ElasticConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
+	Scheme                 string                `yaml:"scheme"`
+	Host                   string                `yaml:"host"`
+	Port                   string                `yaml:"port"`
+	Addresses              []string              `yaml:"addresses"`
+	User                   string                `yaml:"user"`
+	Password               string                `yaml:"password"`
+	APIKey                 string                `yaml:"api_key"`
+	ServiceToken           string                `yaml:"service_token"`
+	CACert                 string                `yaml:"ca_cert"`
+	CertificateFingerprint string                `yaml:"certificate_fingerprint"`
+	DiscoverNodesOnStart   bool                  `yaml:"discover_nodes_on_start"`
+	DiscoverNodesInterval  time.Duration         `yaml:"discover_nodes_interval"`
+	MaxRetries             int                   `yaml:"max_retries"`
+	RetryOnStatus          []int                 `yaml:"retry_on_status"`
+	RetryBackoff           time.Duration         `yaml:"retry_backoff"`
+	StartupTimeout         time.Duration         `yaml:"startup_timeout"`
+	Indices                ElasticIndicesConfig  `yaml:"indices"`
+	Timeouts               ElasticTimeoutsConfig `yaml:"timeouts"`
+	Breaker                BreakerConfig         `yaml:"breaker"`
 }
 
-func Load() (*Config, error) {
-	cfg := &Config{
-		App: AppConfig{
-			Env: getEnv("APP_ENV"),
-		},
+// ElasticIndicesConfig holds the index or alias names queried for each
+// document type.
+type ElasticIndicesConfig struct {
+	Movies  string `yaml:"movies"`
+	Persons string `yaml:"persons"`
+	Genres  string `yaml:"genres"`
+}
+
+type ElasticTimeoutsConfig struct {
+	Get         time.Duration `yaml:"get"`
+	Search      time.Duration `yaml:"search"`
+	Aggregation time.Duration `yaml:"aggregation"`
+}
+
+type BreakerConfig struct {
+	Window       time.Duration `yaml:"window"`
+	MinRequests  int           `yaml:"min_requests"`
+	FailureRatio float64       `yaml:"failure_ratio"`
+	OpenTimeout  time.Duration `yaml:"open_timeout"`
+}
+
+// URLs returns the configured node addresses, falling back to a single node
+// built from the scheme, host and port.
+func (c ElasticConfig) URLs() []string {
+	if len(c.Addresses) > 0 {
+		return c.Addresses
+	}
+	return []string{c.Scheme + "://" + c.Host + ":" + c.Port}
+}
+
+func Default() *Config {
+	return &Config{
 		HTTP: HTTPConfig{
-			Port: getEnv("HTTP_PORT"),
+			Port:              "3000",
+			ReadTimeout:       10 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
 			CORS: CORSConfig{
 				AllowOrigins: []string{"*"},
 				AllowMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 				AllowHeaders: []string{"Content-Type", "Authorization"},
 			},
+			Cache: HTTPCacheConfig{
+				Default: CachePolicy{
+					MaxAge:               time.Minute,
+					StaleWhileRevalidate: 5 * time.Minute,
+					StaleIfError:         24 * time.Hour,
+				},
+				Routes: map[string]CachePolicy{
+					"/genres": {
+						MaxAge:               time.Hour,
+						StaleWhileRevalidate: 24 * time.Hour,
+						StaleIfError:         24 * time.Hour,
+					},
+					"/genres/{id}": {
+						MaxAge:               time.Hour,
+						StaleWhileRevalidate: 24 * time.Hour,
+						StaleIfError:         24 * time.Hour,
+					},
+					"/me/recommendations": {
+						MaxAge:  time.Minute,
+						Private: true,
+					},
+					// Without a seed every request starts a new random order.
+					"/filmworks/discover": {
+						NoStore: true,
+					},
+				},
+			},
+		},
+		GRPC: GRPCConfig{
+			Port: "50051",
 		},
 		Redis: RedisConfig{
-			Host: getEnv("REDIS_HOST"),
-			Port: getEnv("REDIS_PORT"),
-			DB:   getEnv("REDIS_DB"),
+			Port: "6379",
+			DB:   "0",
 		},
 		Elastic: ElasticConfig{
-			Host:     getEnv("ELASTIC_HOST"),
-			Port:     getEnv("ELASTIC_PORT"),
-			User:     getEnv("ELASTIC_USER"),
-			Password: getEnv("ELASTIC_PASSWORD"),
+			Scheme:         "http",
+			Port:           "9200",
+			MaxRetries:     3,
+			RetryOnStatus:  []int{429, 502, 503, 504},
+			RetryBackoff:   100 * time.Millisecond,
+			StartupTimeout: 30 * time.Second,
+			Indices: ElasticIndicesConfig{
+				Movies:  "movies",
+				Persons: "persons",
+				Genres:  "genres",
+			},
+			Timeouts: ElasticTimeoutsConfig{
+				Get:         2 * time.Second,
+				Search:      5 * time.Second,
+				Aggregation: 10 * time.Second,
+			},
+			Breaker: BreakerConfig{
+				Window:       30 * time.Second,
+				MinRequests:  20,
+				FailureRatio: 0.5,
+				OpenTimeout:  15 * time.Second,
+			},
+		},
+		Cache: CacheConfig{
+			TTL:                 time.Minute,
+			StaleTTL:            24 * time.Hour,
+			InvalidationChannel: "catalog:invalidations",
+		},
+		Mongo: MongoConfig{
+			Port:     "27017",
+			Database: "ugc_database",
+			Timeout:  2 * time.Second,
+		},
+		Activity: ActivityConfig{
+			Bucket:           time.Hour,
+			Retention:        30 * 24 * time.Hour,
+			TrendingWindow:   24 * time.Hour,
+			TrendingHalfLife: 24 * time.Hour,
+			PopularHalfLife:  7 * 24 * time.Hour,
+			ViewWeight:       1,
+			VoteWeight:       5,
+			BookmarkWeight:   3,
+			UGCInterval:      5 * time.Minute,
+		},
+		Path: PathConfig{
+			MaxDepth:  6,
+			Timeout:   5 * time.Second,
+			BatchSize: 100,
+		},
+		Stats: StatsConfig{
+			CacheTTL: 30 * time.Second,
+		},
+		Postgres: PostgresConfig{
+			Port: "5432",
+		},
+		Export: ExportConfig{
+			PageSize:  1000,
+			KeepAlive: time.Minute,
 		},
+		ETL: ETLConfig{
+			BatchSize: 500,
+			StateFile: "etl_state.json",
+			Debounce:  500 * time.Millisecond,
+		},
+	}
+}
+
+// Load builds the configuration from defaults, an optional YAML file, the
+// environment and command-line flags, each layer overriding the previous one.
+func Load(args []string) (*Config, error) {
+	return LoadFlagSet(flag.NewFlagSet("async-api", flag.ContinueOnError), args)
+}
+
+// LoadFlagSet is like Load but registers the config flags on flagSet, so that
+// commands can parse their own flags alongside them.
+func LoadFlagSet(flagSet *flag.FlagSet, args []string) (*Config, error) {
+	cfg := Default()
+
+	var overrides []func() error
+	cfg.registerFlags(flagSet, &overrides)
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if cfg.File == "" {
+		cfg.File = getEnv("CONFIG_FILE")
+	}
+	if cfg.File != "" {
+		if err := cfg.loadFile(cfg.File); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	for _, override := range overrides {
+		if err := override(); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -73,32 +423,182 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
 func (c *Config) Validate() error {
+	var errs []error
 	if c.App.Env == "" {
-		return fmt.Errorf("ENV is required")
+		errs = append(errs, fmt.Errorf("APP_ENV is required"))
 	}
 	if c.HTTP.Port == "" {
-		return fmt.Errorf("HTTP_PORT is required")
+		errs = append(errs, fmt.Errorf("HTTP_PORT is required"))
+	}
+	if c.GRPC.Port == "" {
+		errs = append(errs, fmt.Errorf("GRPC_PORT is required"))
+	}
+	if c.HTTP.ReadTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("HTTP_READ_TIMEOUT must be positive"))
+	}
+	if c.HTTP.ReadHeaderTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("HTTP_READ_HEADER_TIMEOUT must be positive"))
+	}
+	if c.HTTP.WriteTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("HTTP_WRITE_TIMEOUT must be positive"))
+	}
+	if c.HTTP.IdleTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("HTTP_IDLE_TIMEOUT must be positive"))
+	}
+	for route, policy := range c.HTTP.Cache.Routes {
+		if policy.MaxAge < 0 || policy.StaleWhileRevalidate < 0 || policy.StaleIfError < 0 {
+			errs = append(errs, fmt.Errorf("http.cache.routes[%s] durations must not be negative", route))
+		}
+	}
+	if c.HTTP.Cache.Default.MaxAge < 0 || c.HTTP.Cache.Default.StaleWhileRevalidate < 0 || c.HTTP.Cache.Default.StaleIfError < 0 {
+		errs = append(errs, fmt.Errorf("HTTP_CACHE_* durations must not be negative"))
+	}
+	if len(c.HTTP.CORS.AllowOrigins) == 0 {
+		errs = append(errs, fmt.Errorf("HTTP_CORS_ALLOW_ORIGINS is required"))
+	}
+	if c.App.Env == "production" && slices.Contains(c.HTTP.CORS.AllowOrigins, "*") {
+		errs = append(errs, fmt.Errorf("HTTP_CORS_ALLOW_ORIGINS must not contain '*' in production"))
+	}
+	if len(c.Elastic.Addresses) == 0 {
+		if c.Elastic.Host == "" {
+			errs = append(errs, fmt.Errorf("ELASTIC_HOST is required"))
+		}
+		if c.Elastic.Port == "" {
+			errs = append(errs, fmt.Errorf("ELASTIC_PORT is required"))
+		}
+	}
+	if c.Elastic.Scheme != "http" && c.Elastic.Scheme != "https" {
+		errs = append(errs, fmt.Errorf("ELASTIC_SCHEME must be http or https"))
 	}
-	if c.Elastic.Host == "" {
-		return fmt.Errorf("ELASTIC_HOST is required")
+	if c.Elastic.User == "" && c.Elastic.APIKey == "" && c.Elastic.ServiceToken == "" {
+		errs = append(errs, fmt.Errorf("one of ELASTIC_USER, ELASTIC_API_KEY or ELASTIC_SERVICE_TOKEN is required"))
 	}
-	if c.Elastic.Port == "" {
-		return fmt.Errorf("ELASTIC_PORT is required")
+	if c.Elastic.CACert != "" && c.Elastic.CertificateFingerprint != "" {
+		errs = append(errs, fmt.Errorf("ELASTIC_CA_CERT and ELASTIC_CERTIFICATE_FINGERPRINT are mutually exclusive"))
 	}
-	if c.Elastic.User == "" {
-		return fmt.Errorf("ELASTIC_USER is required")
+	if c.Elastic.MaxRetries < 0 {
+		errs = append(errs, fmt.Errorf("ELASTIC_MAX_RETRIES must not be negative"))
+	}
+	if c.Elastic.StartupTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("ELASTIC_STARTUP_TIMEOUT must be positive"))
+	}
+	if c.Elastic.Indices.Movies == "" || c.Elastic.Indices.Persons == "" || c.Elastic.Indices.Genres == "" {
+		errs = append(errs, fmt.Errorf("ELASTIC_INDEX_MOVIES, ELASTIC_INDEX_PERSONS and ELASTIC_INDEX_GENRES must not be empty"))
+	}
+	if c.Elastic.Timeouts.Get <= 0 || c.Elastic.Timeouts.Search <= 0 || c.Elastic.Timeouts.Aggregation <= 0 {
+		errs = append(errs, fmt.Errorf("ELASTIC_GET_TIMEOUT, ELASTIC_SEARCH_TIMEOUT and ELASTIC_AGGREGATION_TIMEOUT must be positive"))
+	}
+	if c.Elastic.Breaker.Window <= 0 {
+		errs = append(errs, fmt.Errorf("ELASTIC_BREAKER_WINDOW must be positive"))
+	}
+	if c.Elastic.Breaker.MinRequests <= 0 {
+		errs = append(errs, fmt.Errorf("ELASTIC_BREAKER_MIN_REQUESTS must be positive"))
+	}
+	if c.Elastic.Breaker.FailureRatio <= 0 || c.Elastic.Breaker.FailureRatio > 1 {
+		errs = append(errs, fmt.Errorf("ELASTIC_BREAKER_FAILURE_RATIO must be in (0, 1]"))
+	}
+	if c.Elastic.Breaker.OpenTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("ELASTIC_BREAKER_OPEN_TIMEOUT must be positive"))
 	}
 	if c.Redis.Host == "" {
-		return fmt.Errorf("REDIS_HOST is required")
+		errs = append(errs, fmt.Errorf("REDIS_HOST is required"))
 	}
 	if c.Redis.Port == "" {
-		return fmt.Errorf("REDIS_PORT is required")
+		errs = append(errs, fmt.Errorf("REDIS_PORT is required"))
 	}
 	if c.Redis.DB == "" {
-		return fmt.Errorf("REDIS_DB is required")
+		errs = append(errs, fmt.Errorf("REDIS_DB is required"))
 	}
-	return nil
+	if c.Mongo.Host != "" && c.Mongo.Database == "" {
+		errs = append(errs, fmt.Errorf("MONGO_DATABASE is required when MONGO_HOST is set"))
+	}
+	if c.Mongo.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("MONGO_TIMEOUT must be positive"))
+	}
+	if c.Cache.TTL <= 0 {
+		errs = append(errs, fmt.Errorf("CACHE_TTL must be positive"))
+	}
+	if c.Cache.StaleTTL < 0 {
+		errs = append(errs, fmt.Errorf("CACHE_STALE_TTL must not be negative"))
+	}
+	if c.Cache.InvalidationChannel == "" {
+		errs = append(errs, fmt.Errorf("CACHE_INVALIDATION_CHANNEL is required"))
+	}
+	// Buckets are numbered in whole seconds.
+	if c.Activity.Bucket < time.Second {
+		errs = append(errs, fmt.Errorf("ACTIVITY_BUCKET must be at least 1s"))
+	}
+	if c.Activity.Retention < c.Activity.Bucket {
+		errs = append(errs, fmt.Errorf("ACTIVITY_RETENTION must be at least ACTIVITY_BUCKET"))
+	}
+	if c.Activity.TrendingWindow < c.Activity.Bucket || c.Activity.TrendingWindow > c.Activity.Retention {
+		errs = append(errs, fmt.Errorf("ACTIVITY_TRENDING_WINDOW must be between ACTIVITY_BUCKET and ACTIVITY_RETENTION"))
+	}
+	if c.Activity.TrendingHalfLife <= 0 || c.Activity.PopularHalfLife <= 0 {
+		errs = append(errs, fmt.Errorf("ACTIVITY_*_HALF_LIFE must be positive"))
+	}
+	if c.Activity.ViewWeight < 0 || c.Activity.VoteWeight < 0 || c.Activity.BookmarkWeight < 0 {
+		errs = append(errs, fmt.Errorf("ACTIVITY_*_WEIGHT must not be negative"))
+	}
+	if c.Activity.UGCInterval <= 0 {
+		errs = append(errs, fmt.Errorf("ACTIVITY_UGC_INTERVAL must be positive"))
+	}
+	if c.Path.MaxDepth <= 0 {
+		errs = append(errs, fmt.Errorf("PATH_MAX_DEPTH must be positive"))
+	}
+	if c.Path.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("PATH_TIMEOUT must be positive"))
+	}
+	if c.Path.BatchSize <= 0 || c.Path.BatchSize > 1000 {
+		errs = append(errs, fmt.Errorf("PATH_BATCH_SIZE must be between 1 and 1000"))
+	}
+	if c.Stats.CacheTTL <= 0 {
+		errs = append(errs, fmt.Errorf("STATS_CACHE_TTL must be positive"))
+	}
+	if c.Export.PageSize <= 0 || c.Export.PageSize > 10000 {
+		errs = append(errs, fmt.Errorf("EXPORT_PAGE_SIZE must be between 1 and 10000"))
+	}
+	if c.Export.KeepAlive <= 0 {
+		errs = append(errs, fmt.Errorf("EXPORT_KEEP_ALIVE must be positive"))
+	}
+	if c.ETL.BatchSize <= 0 {
+		errs = append(errs, fmt.Errorf("ETL_BATCH_SIZE must be positive"))
+	}
+	if c.ETL.Interval < 0 {
+		errs = append(errs, fmt.Errorf("ETL_INTERVAL must not be negative"))
+	}
+	if c.ETL.Debounce <= 0 {
+		errs = append(errs, fmt.Errorf("ETL_DEBOUNCE must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// Print writes the effective configuration as YAML with secrets redacted.
+func (c *Config) Print(w io.Writer) error {
+	redacted := *c
+	for _, f := range redacted.fields() {
+		if f.secret && f.value.String() != "" {
+			f.value.Set("[REDACTED]")
+		}
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&redacted); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	return encoder.Close()
 }
 
 func getEnv(key string) string {
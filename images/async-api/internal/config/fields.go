@@ -0,0 +1,260 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type field struct {
+	env    string
+	flag   string
+	usage  string
+	secret bool
+	value  flag.Value
+}
+
+func (c *Config) fields() []field {
+	return []field{
+		{env: "APP_ENV", flag: "env", usage: "application environment", value: (*stringValue)(&c.App.Env)},
+		{env: "HTTP_PORT", flag: "http-port", usage: "HTTP listen port", value: (*stringValue)(&c.HTTP.Port)},
+		{env: "HTTP_READ_TIMEOUT", flag: "http-read-timeout", usage: "HTTP read timeout", value: (*durationValue)(&c.HTTP.ReadTimeout)},
+		{env: "HTTP_READ_HEADER_TIMEOUT", flag: "http-read-header-timeout", usage: "HTTP read header timeout", value: (*durationValue)(&c.HTTP.ReadHeaderTimeout)},
+		{env: "HTTP_WRITE_TIMEOUT", flag: "http-write-timeout", usage: "HTTP write timeout", value: (*durationValue)(&c.HTTP.WriteTimeout)},
+		{env: "HTTP_IDLE_TIMEOUT", flag: "http-idle-timeout", usage: "HTTP idle timeout", value: (*durationValue)(&c.HTTP.IdleTimeout)},
+		{env: "HTTP_CORS_ALLOW_ORIGINS", flag: "cors-allow-origins", usage: "comma-separated CORS allowed origins", value: (*listValue)(&c.HTTP.CORS.AllowOrigins)},
+		{env: "HTTP_CORS_ALLOW_METHODS", flag: "cors-allow-methods", usage: "comma-separated CORS allowed methods", value: (*listValue)(&c.HTTP.CORS.AllowMethods)},
+		{env: "HTTP_CORS_ALLOW_HEADERS", flag: "cors-allow-headers", usage: "comma-separated CORS allowed headers", value: (*listValue)(&c.HTTP.CORS.AllowHeaders)},
+		{env: "HTTP_CACHE_MAX_AGE", flag: "http-cache-max-age", usage: "default Cache-Control max-age of GET responses", value: (*durationValue)(&c.HTTP.Cache.Default.MaxAge)},
+		{env: "HTTP_CACHE_STALE_WHILE_REVALIDATE", flag: "http-cache-stale-while-revalidate", usage: "default Cache-Control stale-while-revalidate of GET responses", value: (*durationValue)(&c.HTTP.Cache.Default.StaleWhileRevalidate)},
+		{env: "HTTP_CACHE_STALE_IF_ERROR", flag: "http-cache-stale-if-error", usage: "default Cache-Control stale-if-error of GET responses", value: (*durationValue)(&c.HTTP.Cache.Default.StaleIfError)},
+		{env: "GRPC_PORT", flag: "grpc-port", usage: "gRPC listen port", value: (*stringValue)(&c.GRPC.Port)},
+		{env: "ELASTIC_SCHEME", flag: "elastic-scheme", usage: "Elasticsearch scheme (http or https)", value: (*stringValue)(&c.Elastic.Scheme)},
+		{env: "ELASTIC_HOST", flag: "elastic-host", usage: "Elasticsearch host", value: (*stringValue)(&c.Elastic.Host)},
+		{env: "ELASTIC_PORT", flag: "elastic-port", usage: "Elasticsearch port", value: (*stringValue)(&c.Elastic.Port)},
+		{env: "ELASTIC_ADDRESSES", flag: "elastic-addresses", usage: "comma-separated Elasticsearch node URLs, overrides host and port", value: (*listValue)(&c.Elastic.Addresses)},
+		{env: "ELASTIC_USER", flag: "elastic-user", usage: "Elasticsearch user", value: (*stringValue)(&c.Elastic.User)},
+		{env: "ELASTIC_PASSWORD", flag: "elastic-password", usage: "Elasticsearch password", secret: true, value: (*stringValue)(&c.Elastic.Password)},
+		{env: "ELASTIC_API_KEY", flag: "elastic-api-key", usage: "Elasticsearch API key", secret: true, value: (*stringValue)(&c.Elastic.APIKey)},
+		{env: "ELASTIC_SERVICE_TOKEN", flag: "elastic-service-token", usage: "Elasticsearch bearer service token", secret: true, value: (*stringValue)(&c.Elastic.ServiceToken)},
+		{env: "ELASTIC_CA_CERT", flag: "elastic-ca-cert", usage: "path to a PEM CA bundle for Elasticsearch", value: (*stringValue)(&c.Elastic.CACert)},
+		{env: "ELASTIC_CERTIFICATE_FINGERPRINT", flag: "elastic-certificate-fingerprint", usage: "SHA256 fingerprint of the Elasticsearch CA", value: (*stringValue)(&c.Elastic.CertificateFingerprint)},
+		{env: "ELASTIC_DISCOVER_NODES_ON_START", flag: "elastic-discover-nodes-on-start", usage: "sniff cluster nodes on start", value: (*boolValue)(&c.Elastic.DiscoverNodesOnStart)},
+		{env: "ELASTIC_DISCOVER_NODES_INTERVAL", flag: "elastic-discover-nodes-interval", usage: "interval for periodic node sniffing", value: (*durationValue)(&c.Elastic.DiscoverNodesInterval)},
+		{env: "ELASTIC_MAX_RETRIES", flag: "elastic-max-retries", usage: "maximum number of Elasticsearch retries", value: (*intValue)(&c.Elastic.MaxRetries)},
+		{env: "ELASTIC_RETRY_ON_STATUS", flag: "elastic-retry-on-status", usage: "comma-separated status codes to retry", value: (*intListValue)(&c.Elastic.RetryOnStatus)},
+		{env: "ELASTIC_RETRY_BACKOFF", flag: "elastic-retry-backoff", usage: "base backoff between Elasticsearch retries", value: (*durationValue)(&c.Elastic.RetryBackoff)},
+		{env: "ELASTIC_STARTUP_TIMEOUT", flag: "elastic-startup-timeout", usage: "time allowed for the startup cluster check", value: (*durationValue)(&c.Elastic.StartupTimeout)},
+		{env: "ELASTIC_INDEX_MOVIES", flag: "elastic-index-movies", usage: "index or alias holding filmworks", value: (*stringValue)(&c.Elastic.Indices.Movies)},
+		{env: "ELASTIC_INDEX_PERSONS", flag: "elastic-index-persons", usage: "index or alias holding persons", value: (*stringValue)(&c.Elastic.Indices.Persons)},
+		{env: "ELASTIC_INDEX_GENRES", flag: "elastic-index-genres", usage: "index or alias holding genres", value: (*stringValue)(&c.Elastic.Indices.Genres)},
+		{env: "ELASTIC_GET_TIMEOUT", flag: "elastic-get-timeout", usage: "time budget for Elasticsearch document lookups", value: (*durationValue)(&c.Elastic.Timeouts.Get)},
+		{env: "ELASTIC_SEARCH_TIMEOUT", flag: "elastic-search-timeout", usage: "time budget for Elasticsearch searches", value: (*durationValue)(&c.Elastic.Timeouts.Search)},
+		{env: "ELASTIC_AGGREGATION_TIMEOUT", flag: "elastic-aggregation-timeout", usage: "time budget for Elasticsearch aggregations", value: (*durationValue)(&c.Elastic.Timeouts.Aggregation)},
+		{env: "ELASTIC_BREAKER_WINDOW", flag: "elastic-breaker-window", usage: "rolling window for the Elasticsearch circuit breaker", value: (*durationValue)(&c.Elastic.Breaker.Window)},
+		{env: "ELASTIC_BREAKER_MIN_REQUESTS", flag: "elastic-breaker-min-requests", usage: "requests in the window before the circuit breaker may trip", value: (*intValue)(&c.Elastic.Breaker.MinRequests)},
+		{env: "ELASTIC_BREAKER_FAILURE_RATIO", flag: "elastic-breaker-failure-ratio", usage: "failure ratio that trips the circuit breaker", value: (*floatValue)(&c.Elastic.Breaker.FailureRatio)},
+		{env: "ELASTIC_BREAKER_OPEN_TIMEOUT", flag: "elastic-breaker-open-timeout", usage: "how long the circuit breaker stays open", value: (*durationValue)(&c.Elastic.Breaker.OpenTimeout)},
+		{env: "REDIS_HOST", flag: "redis-host", usage: "Redis host", value: (*stringValue)(&c.Redis.Host)},
+		{env: "REDIS_PORT", flag: "redis-port", usage: "Redis port", value: (*stringValue)(&c.Redis.Port)},
+		{env: "REDIS_DB", flag: "redis-db", usage: "Redis database number", value: (*stringValue)(&c.Redis.DB)},
+		{env: "EXPORT_TOKENS", flag: "export-tokens", usage: "comma-separated service tokens accepted by /export", secret: true, value: (*listValue)(&c.Export.Tokens)},
+		{env: "EXPORT_PAGE_SIZE", flag: "export-page-size", usage: "documents fetched per export page", value: (*intValue)(&c.Export.PageSize)},
+		{env: "EXPORT_KEEP_ALIVE", flag: "export-keep-alive", usage: "point-in-time keep-alive between export pages", value: (*durationValue)(&c.Export.KeepAlive)},
+		{env: "JWT_SECRET_KEY", flag: "jwt-secret-key", usage: "secret of the HS256 access tokens issued by the auth service", secret: true, value: (*stringValue)(&c.Auth.JWTSecretKey)},
+		{env: "ACTIVITY_BUCKET", flag: "activity-bucket", usage: "time span of one trending activity bucket", value: (*durationValue)(&c.Activity.Bucket)},
+		{env: "ACTIVITY_RETENTION", flag: "activity-retention", usage: "how long trending activity is kept, the largest window", value: (*durationValue)(&c.Activity.Retention)},
+		{env: "ACTIVITY_TRENDING_WINDOW", flag: "activity-trending-window", usage: "default window of /filmworks/trending", value: (*durationValue)(&c.Activity.TrendingWindow)},
+		{env: "ACTIVITY_TRENDING_HALF_LIFE", flag: "activity-trending-half-life", usage: "half-life of activity in the trending ranking", value: (*durationValue)(&c.Activity.TrendingHalfLife)},
+		{env: "ACTIVITY_POPULAR_HALF_LIFE", flag: "activity-popular-half-life", usage: "half-life of activity in the popular ranking", value: (*durationValue)(&c.Activity.PopularHalfLife)},
+		{env: "ACTIVITY_VIEW_WEIGHT", flag: "activity-view-weight", usage: "activity score of a filmwork view", value: (*floatValue)(&c.Activity.ViewWeight)},
+		{env: "ACTIVITY_VOTE_WEIGHT", flag: "activity-vote-weight", usage: "activity score of a UGC vote", value: (*floatValue)(&c.Activity.VoteWeight)},
+		{env: "ACTIVITY_BOOKMARK_WEIGHT", flag: "activity-bookmark-weight", usage: "activity score of a UGC bookmark", value: (*floatValue)(&c.Activity.BookmarkWeight)},
+		{env: "ACTIVITY_UGC_INTERVAL", flag: "activity-ugc-interval", usage: "how often votes and bookmarks are collected for trending", value: (*durationValue)(&c.Activity.UGCInterval)},
+		{env: "PATH_MAX_DEPTH", flag: "path-max-depth", usage: "longest chain of shared filmworks /persons/{a}/path/{b} looks for", value: (*intValue)(&c.Path.MaxDepth)},
+		{env: "PATH_TIMEOUT", flag: "path-timeout", usage: "time budget of a /persons/{a}/path/{b} search", value: (*durationValue)(&c.Path.Timeout)},
+		{env: "PATH_BATCH_SIZE", flag: "path-batch-size", usage: "persons expanded per query of a path search", value: (*intValue)(&c.Path.BatchSize)},
+		{env: "STATS_CACHE_TTL", flag: "stats-cache-ttl", usage: "how long /stats is served from the cache", value: (*durationValue)(&c.Stats.CacheTTL)},
+		{env: "POSTGRES_HOST", flag: "postgres-host", usage: "Postgres host", value: (*stringValue)(&c.Postgres.Host)},
+		{env: "POSTGRES_PORT", flag: "postgres-port", usage: "Postgres port", value: (*stringValue)(&c.Postgres.Port)},
+		{env: "POSTGRES_DB", flag: "postgres-db", usage: "Postgres database", value: (*stringValue)(&c.Postgres.DB)},
+		{env: "POSTGRES_USER", flag: "postgres-user", usage: "Postgres user", value: (*stringValue)(&c.Postgres.User)},
+		{env: "POSTGRES_PASSWORD", flag: "postgres-password", usage: "Postgres password", secret: true, value: (*stringValue)(&c.Postgres.Password)},
+		{env: "ETL_BATCH_SIZE", flag: "etl-batch-size", usage: "rows loaded per ETL batch", value: (*intValue)(&c.ETL.BatchSize)},
+		{env: "ETL_STATE_FILE", flag: "etl-state-file", usage: "file holding the ETL checkpoints", value: (*stringValue)(&c.ETL.StateFile)},
+		{env: "ETL_INTERVAL", flag: "etl-interval", usage: "pause between ETL runs, 0 runs once", value: (*durationValue)(&c.ETL.Interval)},
+		{env: "ETL_LISTEN", flag: "etl-listen", usage: "apply Postgres changes as they happen via LISTEN/NOTIFY", value: (*boolValue)(&c.ETL.Listen)},
+		{env: "ETL_DEBOUNCE", flag: "etl-debounce", usage: "window for batching Postgres change notifications", value: (*durationValue)(&c.ETL.Debounce)},
+		{env: "ETL_PUBLISH_INVALIDATIONS", flag: "etl-publish-invalidations", usage: "publish cache invalidation events for loaded documents", value: (*boolValue)(&c.ETL.PublishInvalidations)},
+		{env: "MONGO_HOST", flag: "mongo-host", usage: "UGC Mongo host, empty disables audience ratings", value: (*stringValue)(&c.Mongo.Host)},
+		{env: "MONGO_PORT", flag: "mongo-port", usage: "UGC Mongo port", value: (*stringValue)(&c.Mongo.Port)},
+		{env: "MONGO_USERNAME", flag: "mongo-username", usage: "UGC Mongo user", value: (*stringValue)(&c.Mongo.User)},
+		{env: "MONGO_PASSWORD", flag: "mongo-password", usage: "UGC Mongo password", secret: true, value: (*stringValue)(&c.Mongo.Password)},
+		{env: "MONGO_DATABASE", flag: "mongo-database", usage: "UGC Mongo database", value: (*stringValue)(&c.Mongo.Database)},
+		{env: "MONGO_TIMEOUT", flag: "mongo-timeout", usage: "timeout of UGC Mongo queries", value: (*durationValue)(&c.Mongo.Timeout)},
+		{env: "CACHE_TTL", flag: "cache-ttl", usage: "how long cached responses are served as fresh", value: (*durationValue)(&c.Cache.TTL)},
+		{env: "CACHE_STALE_TTL", flag: "cache-stale-ttl", usage: "how long expired responses are kept for serving while Elasticsearch is unavailable", value: (*durationValue)(&c.Cache.StaleTTL)},
+		{env: "CACHE_INVALIDATION_CHANNEL", flag: "cache-invalidation-channel", usage: "Redis channel carrying entity change events", value: (*stringValue)(&c.Cache.InvalidationChannel)},
+	}
+}
+
+// registerFlags registers a flag for every field. Flag values are collected as
+// deferred overrides so that they are applied after the file and env layers.
+func (c *Config) registerFlags(flagSet *flag.FlagSet, overrides *[]func() error) {
+	flagSet.StringVar(&c.File, "config", "", "path to a YAML config file")
+	flagSet.BoolVar(&c.PrintConfig, "print-config", false, "print the effective config with secrets redacted and exit")
+
+	for _, f := range c.fields() {
+		flagSet.Func(f.flag, f.usage, func(s string) error {
+			*overrides = append(*overrides, func() error {
+				if err := f.value.Set(s); err != nil {
+					return fmt.Errorf("invalid value for -%s: %w", f.flag, err)
+				}
+				return nil
+			})
+			return nil
+		})
+	}
+}
+
+func (c *Config) loadEnv() error {
+	for _, f := range c.fields() {
+		value, exists := os.LookupEnv(f.env)
+		if f.secret {
+			if path, fileExists := os.LookupEnv(f.env + "_FILE"); fileExists {
+				if exists {
+					return fmt.Errorf("%s and %s_FILE are mutually exclusive", f.env, f.env)
+				}
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return fmt.Errorf("failed to read %s_FILE: %w", f.env, err)
+				}
+				value, exists = strings.TrimRight(string(data), "\r\n"), true
+			}
+		}
+		if !exists || value == "" {
+			continue
+		}
+		if err := f.value.Set(value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", f.env, err)
+		}
+	}
+	return nil
+}
+
+type stringValue string
+
+func (v *stringValue) Set(s string) error {
+	*v = stringValue(s)
+	return nil
+}
+
+func (v *stringValue) String() string {
+	return string(*v)
+}
+
+type durationValue time.Duration
+
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*v = durationValue(d)
+	return nil
+}
+
+func (v *durationValue) String() string {
+	return time.Duration(*v).String()
+}
+
+type intValue int
+
+func (v *intValue) Set(s string) error {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*v = intValue(i)
+	return nil
+}
+
+func (v *intValue) String() string {
+	return strconv.Itoa(int(*v))
+}
+
+type floatValue float64
+
+func (v *floatValue) Set(s string) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*v = floatValue(f)
+	return nil
+}
+
+func (v *floatValue) String() string {
+	return strconv.FormatFloat(float64(*v), 'f', -1, 64)
+}
+
+type boolValue bool
+
+func (v *boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*v = boolValue(b)
+	return nil
+}
+
+func (v *boolValue) String() string {
+	return strconv.FormatBool(bool(*v))
+}
+
+type listValue []string
+
+func (v *listValue) Set(s string) error {
+	items := make([]string, 0)
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*v = items
+	return nil
+}
+
+func (v *listValue) String() string {
+	return strings.Join(*v, ",")
+}
+
+type intListValue []int
+
+func (v *intListValue) Set(s string) error {
+	items := make([]int, 0)
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		i, err := strconv.Atoi(item)
+		if err != nil {
+			return err
+		}
+		items = append(items, i)
+	}
+	*v = items
+	return nil
+}
+
+func (v *intListValue) String() string {
+	items := make([]string, 0, len(*v))
+	for _, i := range *v {
+		items = append(items, strconv.Itoa(i))
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,42 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"async-api/pkg/cache"
+)
+
+type cachedRepository struct {
+	repo  Repository
+	cache *cache.Cache
+}
+
+// NewCachedActivityRepository caches the rankings; recording goes straight to
+// the wrapped repository.
+func NewCachedActivityRepository(repo Repository, c *cache.Cache) Repository {
+	return &cachedRepository{repo: repo, cache: c}
+}
+
+func (r *cachedRepository) RecordView(ctx context.Context, filmworkID string) error {
+	return r.repo.RecordView(ctx, filmworkID)
+}
+
+func (r *cachedRepository) RecordUGC(ctx context.Context, votes map[string]int, bookmarks map[string]int) error {
+	return r.repo.RecordUGC(ctx, votes, bookmarks)
+}
+
+func (r *cachedRepository) Trending(ctx context.Context, window time.Duration, offset int, limit int) ([]Scored, error) {
+	key := fmt.Sprintf("filmworks:trending:%s:%d:%d", window, offset, limit)
+	return cache.Fetch(ctx, r.cache, key, func(ctx context.Context) ([]Scored, error) {
+		return r.repo.Trending(ctx, window, offset, limit)
+	})
+}
+
+func (r *cachedRepository) Popular(ctx context.Context, offset int, limit int) ([]Scored, error) {
+	key := fmt.Sprintf("filmworks:popular:%d:%d", offset, limit)
+	return cache.Fetch(ctx, r.cache, key, func(ctx context.Context) ([]Scored, error) {
+		return r.repo.Popular(ctx, offset, limit)
+	})
+}
@@ -0,0 +1,125 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"async-api/internal/domain/bookmark"
+	"async-api/internal/domain/rating"
+)
+
+const (
+	snapshotKey = "activity:ugc:snapshot"
+	lockKey     = "activity:ugc:lock"
+)
+
+// Collector turns UGC votes and bookmarks into activity. The UGC store keeps
+// no timestamps, so the collector periodically compares the per-filmwork
+// counts with the previous snapshot and records the increase as happening
+// now.
+type Collector struct {
+	repo         Repository
+	ratingRepo   rating.Repository
+	bookmarkRepo bookmark.Repository
+	client       *redis.Client
+	interval     time.Duration
+}
+
+func NewCollector(repo Repository, ratingRepo rating.Repository, bookmarkRepo bookmark.Repository, client *redis.Client, interval time.Duration) *Collector {
+	return &Collector{
+		repo:         repo,
+		ratingRepo:   ratingRepo,
+		bookmarkRepo: bookmarkRepo,
+		client:       client,
+		interval:     interval,
+	}
+}
+
+// Run collects every interval until ctx is cancelled.
+func (c *Collector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		if err := c.Collect(ctx); err != nil {
+			log.Printf("failed to collect UGC activity: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Collect records the votes and bookmarks added since the last snapshot. A
+// short Redis lock keeps instances from diffing against the same snapshot at
+// once, which would count an increase twice. The first run only takes the
+// snapshot, so existing votes do not count as recent.
+func (c *Collector) Collect(ctx context.Context) error {
+	locked, err := c.client.SetNX(ctx, lockKey, 1, c.interval/2).Result()
+	if err != nil {
+		return fmt.Errorf("Redis lock error: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+
+	votes, err := c.ratingRepo.VoteCounts(ctx)
+	if err != nil {
+		return err
+	}
+	bookmarks, err := c.bookmarkRepo.Counts(ctx)
+	if err != nil {
+		return err
+	}
+
+	previous, err := c.client.HGetAll(ctx, snapshotKey).Result()
+	if err != nil {
+		return fmt.Errorf("Redis snapshot error: %w", err)
+	}
+	if len(previous) > 0 {
+		newVotes := increase(votes, previous, "votes:")
+		newBookmarks := increase(bookmarks, previous, "bookmarks:")
+		if err := c.repo.RecordUGC(ctx, newVotes, newBookmarks); err != nil {
+			return err
+		}
+	}
+
+	snapshot := make(map[string]interface{}, len(votes)+len(bookmarks)+1)
+	snapshot["taken_at"] = time.Now().Unix()
+	for id, n := range votes {
+		snapshot["votes:"+id] = n
+	}
+	for id, n := range bookmarks {
+		snapshot["bookmarks:"+id] = n
+	}
+	pipe := c.client.TxPipeline()
+	pipe.Del(ctx, snapshotKey)
+	pipe.HSet(ctx, snapshotKey, snapshot)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("Redis snapshot error: %w", err)
+	}
+	return nil
+}
+
+// increase returns by how much each count grew over the snapshot fields under
+// prefix. Decreases, such as removed bookmarks, are ignored.
+func increase(counts map[string]int, previous map[string]string, prefix string) map[string]int {
+	grown := make(map[string]int)
+	for id, n := range counts {
+		before := 0
+		if value, ok := previous[prefix+id]; ok {
+			before, _ = strconv.Atoi(strings.TrimSpace(value))
+		}
+		if n > before {
+			grown[id] = n - before
+		}
+	}
+	return grown
+}
@@ -0,0 +1,7 @@
+package activity
+
+// Scored is a filmwork id with its decayed activity score.
+type Scored struct {
+	ID    string  `json:"id"`
+	Score float64 `json:"score"`
+}
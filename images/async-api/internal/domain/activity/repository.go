@@ -0,0 +1,129 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"async-api/internal/config"
+)
+
+// ErrInvalidWindow is returned for trending windows shorter than a bucket or
+// longer than the retention.
+var ErrInvalidWindow = errors.New("invalid activity window")
+
+type Repository interface {
+	// RecordView counts a view of filmworkID.
+	RecordView(ctx context.Context, filmworkID string) error
+	// RecordUGC counts new votes and bookmarks, keyed by filmwork id.
+	RecordUGC(ctx context.Context, votes map[string]int, bookmarks map[string]int) error
+	// Trending ranks filmworks by their activity over the last window, or
+	// over the configured default window when window is zero.
+	Trending(ctx context.Context, window time.Duration, offset int, limit int) ([]Scored, error)
+	// Popular ranks filmworks by their activity over the whole retention.
+	Popular(ctx context.Context, offset int, limit int) ([]Scored, error)
+}
+
+type activityRepository struct {
+	client *redis.Client
+	cfg    config.ActivityConfig
+}
+
+// NewActivityRepository keeps activity in one sorted set per bucket, named
+// activity:<bucket number>, mapping filmwork ids to their weighted counts.
+// Buckets expire once they fall out of the retention.
+func NewActivityRepository(client *redis.Client, cfg config.Config) Repository {
+	return &activityRepository{client: client, cfg: cfg.Activity}
+}
+
+func (r *activityRepository) RecordView(ctx context.Context, filmworkID string) error {
+	return r.add(ctx, map[string]float64{filmworkID: r.cfg.ViewWeight})
+}
+
+func (r *activityRepository) RecordUGC(ctx context.Context, votes map[string]int, bookmarks map[string]int) error {
+	scores := make(map[string]float64, len(votes)+len(bookmarks))
+	for id, n := range votes {
+		scores[id] += float64(n) * r.cfg.VoteWeight
+	}
+	for id, n := range bookmarks {
+		scores[id] += float64(n) * r.cfg.BookmarkWeight
+	}
+	return r.add(ctx, scores)
+}
+
+func (r *activityRepository) add(ctx context.Context, scores map[string]float64) error {
+	if len(scores) == 0 {
+		return nil
+	}
+	key := r.bucketKey(r.bucket(time.Now()))
+	pipe := r.client.Pipeline()
+	for id, score := range scores {
+		pipe.ZIncrBy(ctx, key, score, id)
+	}
+	pipe.Expire(ctx, key, r.cfg.Retention+r.cfg.Bucket)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("Redis activity error: %w", err)
+	}
+	return nil
+}
+
+func (r *activityRepository) Trending(ctx context.Context, window time.Duration, offset int, limit int) ([]Scored, error) {
+	if window == 0 {
+		window = r.cfg.TrendingWindow
+	}
+	if window < r.cfg.Bucket || window > r.cfg.Retention {
+		return nil, ErrInvalidWindow
+	}
+	return r.top(ctx, window, r.cfg.TrendingHalfLife, offset, limit)
+}
+
+func (r *activityRepository) Popular(ctx context.Context, offset int, limit int) ([]Scored, error) {
+	return r.top(ctx, r.cfg.Retention, r.cfg.PopularHalfLife, offset, limit)
+}
+
+// top sums the buckets of the last window, the bucket i buckets back weighted
+// by 0.5^(i*bucket/halfLife), and returns a page of the result. The union is
+// stored, read and dropped in one transaction, so concurrent rankings can
+// share the scratch key.
+func (r *activityRepository) top(ctx context.Context, window time.Duration, halfLife time.Duration, offset int, limit int) ([]Scored, error) {
+	current := r.bucket(time.Now())
+	buckets := int(math.Ceil(float64(window) / float64(r.cfg.Bucket)))
+	store := &redis.ZStore{Aggregate: "SUM"}
+	for i := 0; i < buckets; i++ {
+		age := time.Duration(i) * r.cfg.Bucket
+		store.Keys = append(store.Keys, r.bucketKey(current-int64(i)))
+		store.Weights = append(store.Weights, math.Pow(0.5, float64(age)/float64(halfLife)))
+	}
+
+	const scratch = "activity:ranking"
+	var ranking *redis.ZSliceCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZUnionStore(ctx, scratch, store)
+		ranking = pipe.ZRevRangeWithScores(ctx, scratch, int64(offset), int64(offset+limit-1))
+		pipe.Del(ctx, scratch)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Redis activity error: %w", err)
+	}
+
+	scored := make([]Scored, 0, len(ranking.Val()))
+	for _, z := range ranking.Val() {
+		id, _ := z.Member.(string)
+		scored = append(scored, Scored{ID: id, Score: z.Score})
+	}
+	return scored, nil
+}
+
+func (r *activityRepository) bucket(t time.Time) int64 {
+	return t.Unix() / int64(r.cfg.Bucket.Seconds())
+}
+
+func (r *activityRepository) bucketKey(bucket int64) string {
+	return "activity:" + strconv.FormatInt(bucket, 10)
+}
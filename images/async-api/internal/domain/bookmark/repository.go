@@ -0,0 +1,99 @@
+package bookmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"async-api/internal/config"
+	"async-api/pkg/database"
+)
+
+type Repository interface {
+	// GetByUserID returns the ids of the filmworks userID has bookmarked.
+	GetByUserID(ctx context.Context, userID string) ([]string, error)
+	// Counts returns the number of bookmarks of every bookmarked filmwork.
+	Counts(ctx context.Context) (map[string]int, error)
+}
+
+type bookmarkRepository struct {
+	users   *mongo.Collection
+	timeout time.Duration
+}
+
+// NewBookmarkRepository reads bookmarks from the users collection of the UGC
+// database, where each user document holds a bookmarks array of filmwork ids.
+func NewBookmarkRepository(client *mongo.Client, cfg config.Config) Repository {
+	return &bookmarkRepository{
+		users:   client.Database(cfg.Mongo.Database).Collection("users"),
+		timeout: cfg.Mongo.Timeout,
+	}
+}
+
+func (r *bookmarkRepository) GetByUserID(ctx context.Context, userID string) ([]string, error) {
+	user, err := database.UUIDToBinary(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var doc struct {
+		Bookmarks []struct {
+			FilmworkID primitive.Binary `bson:"filmwork_id"`
+		} `bson:"bookmarks"`
+	}
+	opts := options.FindOne().SetProjection(bson.D{{Key: "bookmarks", Value: 1}})
+	err = r.users.FindOne(reqCtx, bson.D{{Key: "_id", Value: user}}, opts).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Mongo request error: %w", err)
+	}
+
+	ids := make([]string, 0, len(doc.Bookmarks))
+	for _, b := range doc.Bookmarks {
+		ids = append(ids, database.BinaryToUUID(b.FilmworkID))
+	}
+	return ids, nil
+}
+
+func (r *bookmarkRepository) Counts(ctx context.Context) (map[string]int, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$bookmarks"}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$bookmarks.filmwork_id"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+	cursor, err := r.users.Aggregate(reqCtx, pipeline, options.Aggregate())
+	if err != nil {
+		return nil, fmt.Errorf("Mongo aggregation error: %w", err)
+	}
+	defer cursor.Close(reqCtx)
+
+	var docs []struct {
+		ID    primitive.Binary `bson:"_id"`
+		Count int              `bson:"count"`
+	}
+	if err := cursor.All(reqCtx, &docs); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	counts := make(map[string]int, len(docs))
+	for _, doc := range docs {
+		counts[database.BinaryToUUID(doc.ID)] = doc.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,167 @@
+package filmwork
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"async-api/internal/domain/genre"
+	"async-api/internal/domain/person"
+	"async-api/internal/index"
+	"async-api/pkg/cache"
+)
+
+type cachedRepository struct {
+	repo   Repository
+	genres genre.Repository
+	cache  *cache.Cache
+}
+
+// NewCachedFilmworkRepository caches repo in c. genres resolves the genre
+// names embedded in filmworks to the ids they are invalidated by.
+func NewCachedFilmworkRepository(repo Repository, genres genre.Repository, c *cache.Cache) Repository {
+	return &cachedRepository{repo: repo, genres: genres, cache: c}
+}
+
+func (r *cachedRepository) GetByID(ctx context.Context, filmworkId string) (*Filmwork, error) {
+	key := "filmworks:id:" + filmworkId
+	return cache.FetchTagged(ctx, r.cache, key, r.filmworkTags(ctx), func(ctx context.Context) (*Filmwork, error) {
+		return r.repo.GetByID(ctx, filmworkId)
+	})
+}
+
+func (r *cachedRepository) Version(ctx context.Context, filmworkId string) (*index.Version, error) {
+	key := "filmworks:version:" + filmworkId
+	tags := func(*index.Version) []string {
+		return []string{cache.Tag(cache.EntityFilmwork, filmworkId)}
+	}
+	return cache.FetchTagged(ctx, r.cache, key, tags, func(ctx context.Context) (*index.Version, error) {
+		return r.repo.Version(ctx, filmworkId)
+	})
+}
+
+func (r *cachedRepository) GetAll(ctx context.Context, page int, size int, sort string, persons PersonFilter) ([]*BaseFilmwork, error) {
+	key := fmt.Sprintf("filmworks:all:%d:%d:%s", page, size, sort)
+	if !persons.IsZero() {
+		key += ":" + persons.String()
+	}
+	return cache.FetchTagged(ctx, r.cache, key, filmworkListTags, func(ctx context.Context) ([]*BaseFilmwork, error) {
+		return r.repo.GetAll(ctx, page, size, sort, persons)
+	})
+}
+
+func (r *cachedRepository) Search(ctx context.Context, q string, limit int) ([]*BaseFilmwork, error) {
+	key := fmt.Sprintf("filmworks:search:%d:%s", limit, q)
+	return cache.FetchTagged(ctx, r.cache, key, filmworkListTags, func(ctx context.Context) ([]*BaseFilmwork, error) {
+		return r.repo.Search(ctx, q, limit)
+	})
+}
+
+func (r *cachedRepository) Suggest(ctx context.Context, q string) (string, error) {
+	return cache.Fetch(ctx, r.cache, "filmworks:suggest:"+q, func(ctx context.Context) (string, error) {
+		return r.repo.Suggest(ctx, q)
+	})
+}
+
+func (r *cachedRepository) Releases(ctx context.Context, from *Date, to *Date, page int, size int) ([]*DatedFilmwork, error) {
+	key := fmt.Sprintf("filmworks:releases:%s:%s:%d:%d", from, to, page, size)
+	return cache.FetchTagged(ctx, r.cache, key, datedListTags, func(ctx context.Context) ([]*DatedFilmwork, error) {
+		return r.repo.Releases(ctx, from, to, page, size)
+	})
+}
+
+func (r *cachedRepository) Anniversaries(ctx context.Context, date Date, page int, size int) ([]*DatedFilmwork, error) {
+	key := fmt.Sprintf("filmworks:anniversaries:%s:%d:%d", &date, page, size)
+	return cache.FetchTagged(ctx, r.cache, key, datedListTags, func(ctx context.Context) ([]*DatedFilmwork, error) {
+		return r.repo.Anniversaries(ctx, date, page, size)
+	})
+}
+
+func (r *cachedRepository) Discover(ctx context.Context, seed int64, filter DiscoverFilter, page int, size int) ([]*BaseFilmwork, error) {
+	key := fmt.Sprintf("filmworks:discover:%d:%d:%d:%s", seed, page, size, filter)
+	tags := func(filmworks []*BaseFilmwork) []string {
+		tags := filmworkListTags(filmworks)
+		if filter.Genre != "" {
+			tags = append(tags, r.genreTags(ctx, filter.Genre)...)
+		}
+		return tags
+	}
+	return cache.FetchTagged(ctx, r.cache, key, tags, func(ctx context.Context) ([]*BaseFilmwork, error) {
+		return r.repo.Discover(ctx, seed, filter, page, size)
+	})
+}
+
+func filmworkTags(f *Filmwork) []string {
+	tags := []string{cache.Tag(cache.EntityFilmwork, f.ID)}
+	for _, persons := range [][]person.BasePerson{f.Actors, f.Writers, f.Directors} {
+		for _, p := range persons {
+			tags = append(tags, cache.Tag(cache.EntityPerson, p.ID))
+		}
+	}
+	return tags
+}
+
+// filmworkTags adds the tags of the genres a filmwork embeds to its own.
+func (r *cachedRepository) filmworkTags(ctx context.Context) func(f *Filmwork) []string {
+	return func(f *Filmwork) []string {
+		return append(filmworkTags(f), r.genreTags(ctx, f.Genres...)...)
+	}
+}
+
+// genreTags returns the tags of the genres with the given names. Filmworks
+// embed genres by name, so a genre that is renamed or deleted could not be
+// traced back to them otherwise.
+func (r *cachedRepository) genreTags(ctx context.Context, names ...string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	genres, err := r.genres.GetByNames(ctx, names)
+	if err != nil {
+		log.Printf("cache genre tags %v: %v", names, err)
+		return nil
+	}
+	tags := make([]string, 0, len(genres))
+	for _, g := range genres {
+		tags = append(tags, cache.Tag(cache.EntityGenre, g.ID))
+	}
+	return tags
+}
+
+func filmworkListTags(filmworks []*BaseFilmwork) []string {
+	tags := make([]string, 0, len(filmworks))
+	for _, f := range filmworks {
+		tags = append(tags, cache.Tag(cache.EntityFilmwork, f.ID))
+	}
+	return tags
+}
+
+func (r *cachedRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*Filmwork, error) {
+	key := func(id string) string { return "filmworks:id:" + id }
+	return cache.FetchMany(ctx, r.cache, ids, key, r.filmworkTags(ctx), r.repo.GetByIDs)
+}
+
+func (r *cachedRepository) GetSource(ctx context.Context, filmworkId string, includes []string) (map[string]json.RawMessage, error) {
+	key := fmt.Sprintf("filmworks:source:%s:%s", filmworkId, strings.Join(includes, ","))
+	tags := func(map[string]json.RawMessage) []string {
+		return []string{cache.Tag(cache.EntityFilmwork, filmworkId)}
+	}
+	return cache.FetchTagged(ctx, r.cache, key, tags, func(ctx context.Context) (map[string]json.RawMessage, error) {
+		return r.repo.GetSource(ctx, filmworkId, includes)
+	})
+}
+
+// Recommend is not cached: profiles are personal and change with every
+// bookmark and vote.
+func (r *cachedRepository) Recommend(ctx context.Context, profile Profile, exclude []string, audience Audience, size int) ([]*BaseFilmwork, error) {
+	return r.repo.Recommend(ctx, profile, exclude, audience, size)
+}
+
+func datedListTags(filmworks []*DatedFilmwork) []string {
+	tags := make([]string, 0, len(filmworks))
+	for _, f := range filmworks {
+		tags = append(tags, cache.Tag(cache.EntityFilmwork, f.ID))
+	}
+	return tags
+}
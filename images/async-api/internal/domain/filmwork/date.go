@@ -0,0 +1,57 @@
+package filmwork
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date, encoded in JSON as "2006-01-02". Many filmworks
+// have no release date; they carry a nil *Date, encoded as null.
+type Date struct {
+	time.Time
+}
+
+// ParseDate parses a "2006-01-02" date.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
+// Today returns the current date in UTC, the time zone of the index.
+func Today() Date {
+	y, m, d := time.Now().UTC().Date()
+	return Date{time.Date(y, m, d, 0, 0, 0, 0, time.UTC)}
+}
+
+// String formats d as "2006-01-02", or returns an empty string for a nil d.
+func (d *Date) String() string {
+	if d == nil {
+		return ""
+	}
+	return d.Format(time.DateOnly)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format(time.DateOnly))
+}
+
+// UnmarshalJSON accepts dates with or without a time of day, both of which
+// the release_date mapping allows.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	for _, layout := range []string{time.DateOnly, time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			y, m, day := t.Date()
+			d.Time = time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid date %q", s)
+}
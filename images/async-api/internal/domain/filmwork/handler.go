@@ -1,13 +1,22 @@
 package filmwork
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 
+	"async-api/internal/auth"
+	"async-api/internal/domain/activity"
+	"async-api/internal/domain/person"
 	"async-api/internal/http"
+	"async-api/internal/httpcache"
+	"async-api/pkg/cache"
 )
 
 type FilmworkHandler struct {
@@ -20,64 +29,486 @@ func NewFilmworkHandler(service FilmworkService) *FilmworkHandler {
 
 func (h *FilmworkHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/filmworks/search", h.Search).Methods("GET")
+	router.HandleFunc("/filmworks/trending", h.Trending).Methods("GET")
+	router.HandleFunc("/filmworks/popular", h.Popular).Methods("GET")
+	router.HandleFunc("/filmworks/releases", h.Releases).Methods("GET")
+	router.HandleFunc("/filmworks/upcoming", h.Upcoming).Methods("GET")
+	router.HandleFunc("/filmworks/anniversaries", h.Anniversaries).Methods("GET")
+	router.HandleFunc("/filmworks/discover", h.Discover).Methods("GET")
+	router.HandleFunc("/filmworks/batch", h.GetByIDs).Methods("POST")
+	router.HandleFunc("/filmworks", h.GetByIDs).Methods("GET").Queries("ids", "{ids}")
 	router.HandleFunc("/filmworks", h.GetAll).Methods("GET")
 	router.HandleFunc("/filmworks/{id}", h.GetByID).Methods("GET")
 }
 
+// RegisterUserRoutes registers the routes of the authenticated user on a
+// router mounted at /me.
+func (h *FilmworkHandler) RegisterUserRoutes(router *mux.Router) {
+	router.HandleFunc("/recommendations", h.Recommend).Methods("GET")
+}
+
 func (h *FilmworkHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
-	g, err := h.service.GetByID(r.Context(), id)
+	fields, err := response.ReadList(r, "fields", Fields)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	expand, err := response.ReadList(r, "expand", Expandable)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "not found") {
-			statusCode = http.StatusNotFound
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(fields) > 0 || len(expand) > 0 {
+		view, err := h.service.GetByIDView(r.Context(), id, fields, expand)
+		if err != nil {
+			response.SendServiceErrorResponse(w, err)
+			return
 		}
-		response.SendErrorResponse(w, err.Error(), statusCode)
+		h.service.RecordView(r.Context(), id)
+		httpcache.SetSurrogateKeys(w, cache.Tag(cache.EntityFilmwork, id))
+		response.SendSuccessResponse(w, view, http.StatusOK)
+		return
+	}
+	revision, err := h.service.Revision(r.Context(), id)
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
 		return
 	}
+	validators := httpcache.Validators{ETag: httpcache.VersionETag(revision)}
+	// The audience rating changes without the document and has no time.
+	if revision.Rating == nil {
+		validators.LastModified = revision.Version.Modified
+	}
+	if httpcache.NotModified(w, r, validators) {
+		h.service.RecordView(r.Context(), id)
+		return
+	}
+	g, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	h.service.RecordView(r.Context(), id)
+	httpcache.SetSurrogateKeys(w, filmworkTags(g)...)
 	response.SendSuccessResponse(w, g, http.StatusOK)
 }
 
 func (h *FilmworkHandler) Search(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
+	suggest, autocorrect, err := response.ReadSuggest(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if suggest {
+		result, err := h.service.SearchWithSuggestion(r.Context(), query, 1000, autocorrect)
+		if err != nil {
+			sendSearchError(w, err)
+			return
+		}
+		httpcache.SetSurrogateKeys(w, filmworkListTags(result.Items)...)
+		response.SendSuccessResponse(w, result, http.StatusOK)
+		return
+	}
 	filmworks, err := h.service.Search(r.Context(), query, 1000)
 	if err != nil {
-		response.SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		sendSearchError(w, err)
 		return
 	}
+	httpcache.SetSurrogateKeys(w, filmworkListTags(filmworks)...)
 	response.SendSuccessResponse(w, filmworks, http.StatusOK)
 }
 
+// sendSearchError answers a malformed q with 400 pointing at the problem.
+func sendSearchError(w http.ResponseWriter, err error) {
+	var queryErr *QueryError
+	if errors.As(err, &queryErr) {
+		response.SendSyntaxErrorResponse(w, fmt.Sprintf("Неверный формат q на позиции %d: %s", queryErr.Pos, queryErr.Msg), queryErr.Pos)
+		return
+	}
+	response.SendServiceErrorResponse(w, err)
+}
+
 func (h *FilmworkHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	pageNumberStr := r.URL.Query().Get("page_number")
-	pageSizeStr := r.URL.Query().Get("page_size")
+	pageNumber, pageSize, err := readPage(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sort := r.URL.Query().Get("sort")
+	if sort != "" && !slices.Contains(Sorts, sort) {
+		response.SendErrorResponse(w, "Неверный формат sort", http.StatusBadRequest)
+		return
+	}
+	persons, err := readPersonFilter(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filmworks, err := h.service.GetAll(r.Context(), pageNumber, pageSize, sort, persons)
+	if err != nil {
+		if errors.Is(err, ErrRatingsUnavailable) {
+			response.SendErrorResponse(w, "Сортировка по user_rating недоступна", http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, ErrPersonFilterSort) {
+			response.SendErrorResponse(w, "Сортировка по user_rating недоступна с фильтром по персонам", http.StatusBadRequest)
+			return
+		}
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	httpcache.SetSurrogateKeys(w, filmworkListTags(filmworks)...)
+	response.SendSuccessResponse(w, filmworks, http.StatusOK)
+}
+
+func (h *FilmworkHandler) Trending(w http.ResponseWriter, r *http.Request) {
+	pageNumber, pageSize, err := readPage(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var window time.Duration
+	if windowStr := r.URL.Query().Get("window"); windowStr != "" {
+		window, err = parseWindow(windowStr)
+		if err != nil {
+			response.SendErrorResponse(w, "Неверный формат window", http.StatusBadRequest)
+			return
+		}
+	}
+	filmworks, err := h.service.Trending(r.Context(), window, pageNumber, pageSize)
+	if err != nil {
+		if errors.Is(err, activity.ErrInvalidWindow) {
+			response.SendErrorResponse(w, "Недопустимое значение window", http.StatusBadRequest)
+			return
+		}
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	httpcache.SetSurrogateKeys(w, filmworkListTags(filmworks)...)
+	response.SendSuccessResponse(w, filmworks, http.StatusOK)
+}
+
+func (h *FilmworkHandler) Popular(w http.ResponseWriter, r *http.Request) {
+	pageNumber, pageSize, err := readPage(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filmworks, err := h.service.Popular(r.Context(), pageNumber, pageSize)
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	httpcache.SetSurrogateKeys(w, filmworkListTags(filmworks)...)
+	response.SendSuccessResponse(w, filmworks, http.StatusOK)
+}
+
+func (h *FilmworkHandler) Releases(w http.ResponseWriter, r *http.Request) {
+	pageNumber, pageSize, err := readPage(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	from, err := readDate(r, "from")
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err := readDate(r, "to")
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if from != nil && to != nil && to.Before(from.Time) {
+		response.SendErrorResponse(w, "Дата to раньше даты from", http.StatusBadRequest)
+		return
+	}
+	filmworks, err := h.service.Releases(r.Context(), from, to, pageNumber, pageSize)
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	httpcache.SetSurrogateKeys(w, datedListTags(filmworks)...)
+	response.SendSuccessResponse(w, filmworks, http.StatusOK)
+}
+
+func (h *FilmworkHandler) Upcoming(w http.ResponseWriter, r *http.Request) {
+	pageNumber, pageSize, err := readPage(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filmworks, err := h.service.Upcoming(r.Context(), pageNumber, pageSize)
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	httpcache.SetSurrogateKeys(w, datedListTags(filmworks)...)
+	response.SendSuccessResponse(w, filmworks, http.StatusOK)
+}
+
+func (h *FilmworkHandler) Anniversaries(w http.ResponseWriter, r *http.Request) {
+	pageNumber, pageSize, err := readPage(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	date, err := readDate(r, "date")
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if date == nil {
+		today := Today()
+		date = &today
+	}
+	filmworks, err := h.service.Anniversaries(r.Context(), *date, pageNumber, pageSize)
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	httpcache.SetSurrogateKeys(w, datedListTags(filmworks)...)
+	response.SendSuccessResponse(w, filmworks, http.StatusOK)
+}
+
+// Discover lists the filmworks matching the genre, type, min_rating and
+// audience parameters in a random order. Requests with the seed of a previous
+// response page through the same order.
+func (h *FilmworkHandler) Discover(w http.ResponseWriter, r *http.Request) {
+	pageNumber, pageSize, err := readPage(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var seed *int64
+	if seedStr := r.URL.Query().Get("seed"); seedStr != "" {
+		s, err := strconv.ParseInt(seedStr, 10, 64)
+		if err != nil {
+			response.SendErrorResponse(w, "Неверный формат seed", http.StatusBadRequest)
+			return
+		}
+		seed = &s
+	}
+	filter := DiscoverFilter{Genre: r.URL.Query().Get("genre")}
+	if filter.Type = r.URL.Query().Get("type"); filter.Type != "" && !slices.Contains(Types, filter.Type) {
+		response.SendErrorResponse(w, "Неверный формат type", http.StatusBadRequest)
+		return
+	}
+	if minRatingStr := r.URL.Query().Get("min_rating"); minRatingStr != "" {
+		minRating, err := strconv.ParseFloat(minRatingStr, 64)
+		if err != nil || minRating < 0 || minRating > 10 {
+			response.SendErrorResponse(w, "Неверный формат min_rating", http.StatusBadRequest)
+			return
+		}
+		filter.MinRating = &minRating
+	}
+	if filter.Audience, err = readAudience(r); err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	discovery, err := h.service.Discover(r.Context(), seed, filter, pageNumber, pageSize)
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	httpcache.SetSurrogateKeys(w, filmworkListTags(discovery.Items)...)
+	response.SendSuccessResponse(w, discovery, http.StatusOK)
+}
+
+// maxFilterPersons bounds the persons of a person filter, each of which adds
+// nested clauses to the query.
+const maxFilterPersons = 10
+
+// readPersonFilter reads with_persons and without_persons, comma-separated
+// person ids, and their name-based variants with_person_names and
+// without_person_names. Each person may be suffixed with :actor, :director or
+// :writer to match that role only; other colons in names are kept.
+func readPersonFilter(r *http.Request) (PersonFilter, error) {
+	var filter PersonFilter
+	for _, param := range []struct {
+		name   string
+		byName bool
+		refs   *[]PersonRef
+	}{
+		{"with_persons", false, &filter.With},
+		{"with_person_names", true, &filter.With},
+		{"without_persons", false, &filter.Without},
+		{"without_person_names", true, &filter.Without},
+	} {
+		raw := r.URL.Query().Get(param.name)
+		if raw == "" {
+			continue
+		}
+		for _, item := range strings.Split(raw, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			value, role := item, ""
+			if i := strings.LastIndex(item, ":"); i >= 0 {
+				value, role = strings.TrimSpace(item[:i]), strings.TrimSpace(item[i+1:])
+				if !slices.Contains(person.Roles, role) {
+					if !param.byName {
+						return PersonFilter{}, fmt.Errorf("Неверное значение %s: %s", param.name, item)
+					}
+					// Without a role suffix the colon is part of the name.
+					value, role = item, ""
+				}
+				if value == "" {
+					return PersonFilter{}, fmt.Errorf("Неверное значение %s: %s", param.name, item)
+				}
+			}
+			ref := PersonRef{ID: value, Role: role}
+			if param.byName {
+				ref = PersonRef{Name: value, Role: role}
+			}
+			*param.refs = append(*param.refs, ref)
+		}
+	}
+	if len(filter.With)+len(filter.Without) > maxFilterPersons {
+		return PersonFilter{}, fmt.Errorf("Не более %d персон в фильтре", maxFilterPersons)
+	}
+	return filter, nil
+}
+
+// readDate reads the "2006-01-02" date parameter name, or nil when it is not
+// set.
+func readDate(r *http.Request, name string) (*Date, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	date, err := ParseDate(raw)
+	if err != nil {
+		return nil, fmt.Errorf("Неверный формат %s", name)
+	}
+	return &date, nil
+}
+
+// readPage reads page_number and page_size, defaulting to the first page of
+// 100 and capping the size at 100.
+func readPage(r *http.Request) (int, int, error) {
 	pageNumber := 1
 	pageSize := 100
-	if pageNumberStr != "" {
+	if pageNumberStr := r.URL.Query().Get("page_number"); pageNumberStr != "" {
 		if p, err := strconv.Atoi(pageNumberStr); err == nil && p > 0 {
 			pageNumber = p
 		} else {
-			response.SendErrorResponse(w, "Неверный формат page_number", http.StatusBadRequest)
-			return
+			return 0, 0, fmt.Errorf("Неверный формат page_number")
 		}
 	}
-	if pageSizeStr != "" {
+	if pageSizeStr := r.URL.Query().Get("page_size"); pageSizeStr != "" {
 		if s, err := strconv.Atoi(pageSizeStr); err == nil && s > 0 {
-			if s > 100 {
-				pageSize = 100
-			} else {
-				pageSize = s
-			}
+			pageSize = min(s, 100)
+		} else {
+			return 0, 0, fmt.Errorf("Неверный формат page_size")
+		}
+	}
+	return pageNumber, pageSize, nil
+}
+
+// parseWindow parses a duration such as "7d", "12h" or "90m"; d stands for 24
+// hours.
+func parseWindow(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid window '%s'", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	window, err := time.ParseDuration(s)
+	if err != nil || window <= 0 {
+		return 0, fmt.Errorf("invalid window '%s'", s)
+	}
+	return window, nil
+}
+
+func (h *FilmworkHandler) GetByIDs(w http.ResponseWriter, r *http.Request) {
+	ids, err := response.ReadIDs(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	batch, err := h.service.GetByIDs(r.Context(), ids)
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	var keys []string
+	for _, f := range batch.Items {
+		keys = append(keys, filmworkTags(f)...)
+	}
+	httpcache.SetSurrogateKeys(w, keys...)
+	response.SendSuccessResponse(w, batch, http.StatusOK)
+}
+
+// Recommend suggests filmworks to the authenticated user. The age_rating and
+// subscription parameters can only narrow the audience the token grants.
+func (h *FilmworkHandler) Recommend(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.UserClaims(r.Context())
+	if !ok {
+		response.SendErrorResponse(w, "Требуется авторизация", http.StatusUnauthorized)
+		return
+	}
+	audience, err := readAudience(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	audience = audience.Within(tokenAudience(claims))
+	pageSize := 20
+	if pageSizeStr := r.URL.Query().Get("page_size"); pageSizeStr != "" {
+		if s, err := strconv.Atoi(pageSizeStr); err == nil && s > 0 {
+			pageSize = min(s, 100)
 		} else {
 			response.SendErrorResponse(w, "Неверный формат page_size", http.StatusBadRequest)
 			return
 		}
 	}
-	filmworks, err := h.service.GetAll(r.Context(), pageNumber, pageSize)
+	filmworks, err := h.service.Recommend(r.Context(), claims.Subject, audience, pageSize)
 	if err != nil {
-		response.SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		if errors.Is(err, ErrRecommendationsUnavailable) {
+			response.SendErrorResponse(w, "Рекомендации недоступны", http.StatusServiceUnavailable)
+			return
+		}
+		response.SendServiceErrorResponse(w, err)
 		return
 	}
+	httpcache.SetSurrogateKeys(w, filmworkListTags(filmworks)...)
 	response.SendSuccessResponse(w, filmworks, http.StatusOK)
 }
+
+// tokenAudience returns the audience claims grant. Without a valid age_rating
+// claim only filmworks of the lowest age rating are allowed.
+func tokenAudience(claims *auth.Claims) Audience {
+	audience := Audience{MaxAgeRating: AgeRatings[0], Subscriber: claims.Subscriber}
+	if slices.Contains(AgeRatings, claims.AgeRating) {
+		audience.MaxAgeRating = claims.AgeRating
+	}
+	return audience
+}
+
+// readAudience reads the age_rating (the highest allowed) and subscription
+// query parameters. Without them only public filmworks of any age rating are
+// allowed.
+func readAudience(r *http.Request) (Audience, error) {
+	var audience Audience
+	if ageRating := r.URL.Query().Get("age_rating"); ageRating != "" {
+		if !slices.Contains(AgeRatings, ageRating) {
+			return Audience{}, fmt.Errorf("Неверный формат age_rating")
+		}
+		audience.MaxAgeRating = ageRating
+	}
+	if subscription := r.URL.Query().Get("subscription"); subscription != "" {
+		subscriber, err := strconv.ParseBool(subscription)
+		if err != nil {
+			return Audience{}, fmt.Errorf("Неверный формат subscription")
+		}
+		audience.Subscriber = subscriber
+	}
+	return audience, nil
+}
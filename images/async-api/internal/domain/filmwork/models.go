@@ -1,22 +1,174 @@
 package filmwork
 
-import "async-api/internal/domain/person"
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"async-api/internal/domain/person"
+	"async-api/internal/domain/rating"
+	"async-api/internal/index"
+)
+
+// Fields are the Filmwork fields that can be requested with fields=.
+var Fields = []string{"id", "title", "rating", "user_rating", "votes_count", "description", "release_date", "type", "genres", "actors", "writers", "directors"}
+
+// ratingFields are the Fields that come from UGC votes rather than from the
+// filmwork document.
+var ratingFields = []string{"user_rating", "votes_count"}
+
+// Sorts are the orders accepted by sort= on filmwork listings.
+var Sorts = []string{"rating", "-rating", "user_rating", "-user_rating"}
+
+// Expandable are the Filmwork fields that expand= resolves into full genre and
+// person objects.
+var Expandable = []string{"genres", "actors", "writers", "directors"}
+
+// AgeRatings are the age ratings of the catalogue, from the youngest audience
+// to adults only.
+var AgeRatings = []string{"G", "PG", "PG-13", "R", "NC-17"}
+
+// Types are the kinds of filmwork in the catalogue.
+var Types = []string{"movie", "tv_show"}
+
+// Audience restricts a listing to what a viewer may watch: filmworks rated at
+// most MaxAgeRating, if set, and subscription titles only for subscribers.
+type Audience struct {
+	MaxAgeRating string
+	Subscriber   bool
+}
+
+// Within narrows a to what limit allows: the lower of the two age ratings and
+// subscription titles only if both allow them.
+func (a Audience) Within(limit Audience) Audience {
+	narrowed := Audience{MaxAgeRating: a.MaxAgeRating, Subscriber: a.Subscriber && limit.Subscriber}
+	if i := slices.Index(AgeRatings, limit.MaxAgeRating); i >= 0 {
+		if j := slices.Index(AgeRatings, a.MaxAgeRating); j < 0 || j > i {
+			narrowed.MaxAgeRating = limit.MaxAgeRating
+		}
+	}
+	return narrowed
+}
+
+// Profile weighs the genres, directors and actors a user is drawn to. Persons
+// are keyed by id.
+type Profile struct {
+	Genres    map[string]float64
+	Directors map[string]float64
+	Actors    map[string]float64
+}
+
+// PersonRef is a person of a PersonFilter, given by id or, when ID is empty,
+// by name. Role restricts the match to one role; empty matches any.
+type PersonRef struct {
+	ID   string
+	Name string
+	Role string
+}
+
+// PersonFilter keeps the filmworks every With person takes part in and none
+// of the Without persons does.
+type PersonFilter struct {
+	With    []PersonRef
+	Without []PersonRef
+}
+
+func (f PersonFilter) IsZero() bool {
+	return len(f.With) == 0 && len(f.Without) == 0
+}
+
+// String encodes f for cache keys.
+func (f PersonFilter) String() string {
+	refs := func(refs []PersonRef) string {
+		parts := make([]string, 0, len(refs))
+		for _, r := range refs {
+			if r.ID != "" {
+				parts = append(parts, "id="+r.ID+"@"+r.Role)
+			} else {
+				parts = append(parts, "name="+r.Name+"@"+r.Role)
+			}
+		}
+		return strings.Join(parts, ",")
+	}
+	return refs(f.With) + "|" + refs(f.Without)
+}
+
+// DiscoverFilter narrows /filmworks/discover to a genre, a type and a minimum
+// editorial rating, where set, and to what Audience may watch.
+type DiscoverFilter struct {
+	Genre     string
+	Type      string
+	MinRating *float64
+	Audience  Audience
+}
+
+// String encodes f for cache keys.
+func (f DiscoverFilter) String() string {
+	minRating := ""
+	if f.MinRating != nil {
+		minRating = strconv.FormatFloat(*f.MinRating, 'f', -1, 64)
+	}
+	return strings.Join([]string{f.Genre, f.Type, minRating, f.Audience.MaxAgeRating, strconv.FormatBool(f.Audience.Subscriber)}, "|")
+}
+
+// Discovery is a page of the random order of /filmworks/discover. Passing
+// Seed back returns the following pages of the same order.
+type Discovery struct {
+	Seed  int64           `json:"seed"`
+	Items []*BaseFilmwork `json:"items"`
+}
 
 type BaseFilmwork struct {
-	ID     string  `json:"uuid"`
-	Title  string  `json:"title"`
-	Rating float32 `json:"rating"`
+	ID         string   `json:"uuid"`
+	Title      string   `json:"title"`
+	Rating     float32  `json:"rating"`
+	UserRating *float64 `json:"user_rating"`
+	VotesCount int      `json:"votes_count"`
+}
+
+// DatedFilmwork is a BaseFilmwork in a listing by release date. Years is the
+// anniversary a filmwork celebrates in /filmworks/anniversaries.
+type DatedFilmwork struct {
+	BaseFilmwork
+	ReleaseDate *Date `json:"release_date"`
+	Years       int   `json:"years,omitempty"`
 }
 
 type Filmwork struct {
 	ID          string              `json:"id"`
 	Title       string              `json:"title"`
 	Rating      float32             `json:"rating"`
+	UserRating  *float64            `json:"user_rating"`
+	VotesCount  int                 `json:"votes_count"`
 	Description string              `json:"description"`
-	ReleaseDate string              `json:"release_date"`
+	ReleaseDate *Date               `json:"release_date"`
 	Type        string              `json:"type"`
 	Genres      []string            `json:"genres"`
 	Actors      []person.BasePerson `json:"actors"`
 	Writers     []person.BasePerson `json:"writers"`
 	Directors   []person.BasePerson `json:"directors"`
 }
+
+// Revision identifies the representation GetByID returns for a filmwork: the
+// version of its document and the audience rating merged into it, which
+// changes without the document. Rating is nil while ratings are not merged.
+type Revision struct {
+	Version index.Version  `json:"version"`
+	Rating  *rating.Rating `json:"rating,omitempty"`
+}
+
+// FilmworkSearch is a search result with a spelling suggestion for queries
+// that found nothing. Corrected is set when Items are the results of the
+// suggestion instead of the query.
+type FilmworkSearch struct {
+	Items      []*BaseFilmwork `json:"items"`
+	Suggestion *string         `json:"suggestion"`
+	Corrected  bool            `json:"corrected"`
+}
+
+// FilmworkBatch is the result of a batch lookup: the found filmworks in request
+// order and the ids that do not exist.
+type FilmworkBatch struct {
+	Items   []*Filmwork `json:"items"`
+	Missing []string    `json:"missing"`
+}
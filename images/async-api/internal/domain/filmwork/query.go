@@ -0,0 +1,378 @@
+package filmwork
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// QueryError reports a malformed /filmworks/search query. Pos is the 1-based
+// position, in characters, of the offending part of the query.
+type QueryError struct {
+	Pos int
+	Msg string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("query error at position %d: %s", e.Pos, e.Msg)
+}
+
+// Query is a parsed /filmworks/search query. A query is a whitespace
+// separated list of terms, each an optional "-" negating it followed by
+// either a free word or field:value, for example
+//
+//	title:"solaris" genre:Drama rating:>=7.5 year:1970..1980 actor:"Banionis" -genre:Horror
+//
+// Values are bare words or double quoted phrases with \" and \\ escapes.
+// Numeric fields take a number, a comparison (>=7.5, <8) or an inclusive
+// range with optional ends (1970..1980, 7..). A word before a colon that is
+// not a field name, as in "Mission: Impossible", is free text.
+type Query struct {
+	// Text is the free text of the query, searched as before.
+	Text string
+
+	must    []map[string]interface{}
+	filter  []map[string]interface{}
+	mustNot []map[string]interface{}
+}
+
+// queryField turns the value of a field term into a query clause; scored
+// clauses rank the results, the others only filter them.
+type queryField struct {
+	clause func(value string, quoted bool, pos int) (map[string]interface{}, error)
+	scored bool
+}
+
+var queryFields = map[string]queryField{
+	"title":       {clause: textClause("title"), scored: true},
+	"description": {clause: textClause("description"), scored: true},
+	"actor":       {clause: personClause("actors"), scored: true},
+	"director":    {clause: personClause("directors"), scored: true},
+	"writer":      {clause: personClause("writers"), scored: true},
+	"person":      {clause: personClause("actors", "directors", "writers"), scored: true},
+	"genre":       {clause: termClause("genres")},
+	"type":        {clause: termClause("type")},
+	"rating":      {clause: ratingClause},
+	"year":        {clause: yearClause},
+}
+
+// ParseQuery parses q. Malformed queries fail with a *QueryError.
+func ParseQuery(q string) (*Query, error) {
+	p := &queryParser{input: []rune(q)}
+	query := &Query{}
+	var text []string
+	for {
+		p.skipSpace()
+		if p.eof() {
+			break
+		}
+		negated := false
+		if p.peek() == '-' && p.pos+1 < len(p.input) && !unicode.IsSpace(p.input[p.pos+1]) {
+			negated = true
+			p.pos++
+		}
+
+		name, field, ok := p.field()
+		valuePos := p.pos
+		value, quoted, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			if negated {
+				// Excluding fuzzy matches would drop far more than the word.
+				query.mustNot = append(query.mustNot, map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"query":  value,
+						"fields": []string{"title", "description"},
+						"type":   phraseType(quoted),
+					},
+				})
+			} else if value != "" {
+				text = append(text, value)
+			}
+			continue
+		}
+		if value == "" && !quoted {
+			return nil, p.errorAt(valuePos, fmt.Sprintf("missing value of %s", name))
+		}
+		clause, err := field.clause(value, quoted, valuePos+1)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case negated:
+			query.mustNot = append(query.mustNot, clause)
+		case field.scored:
+			query.must = append(query.must, clause)
+		default:
+			query.filter = append(query.filter, clause)
+		}
+	}
+	query.Text = strings.Join(text, " ")
+	return query, nil
+}
+
+// IsFreeText reports whether the query has no field or negated terms.
+func (q *Query) IsFreeText() bool {
+	return len(q.must) == 0 && len(q.filter) == 0 && len(q.mustNot) == 0
+}
+
+// Clause returns the query clause the query runs as. Free text alone runs as
+// before the query language existed.
+func (q *Query) Clause() map[string]interface{} {
+	if q.IsFreeText() {
+		return textQuery(q.Text)
+	}
+	boolQuery := map[string]interface{}{}
+	must := q.must
+	if q.Text != "" {
+		must = append([]map[string]interface{}{textQuery(q.Text)}, must...)
+	}
+	if len(must) > 0 {
+		boolQuery["must"] = must
+	}
+	if len(q.filter) > 0 {
+		boolQuery["filter"] = q.filter
+	}
+	if len(q.mustNot) > 0 {
+		boolQuery["must_not"] = q.mustNot
+	}
+	return map[string]interface{}{"bool": boolQuery}
+}
+
+type queryParser struct {
+	input []rune
+	pos   int
+}
+
+func (p *queryParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *queryParser) peek() rune {
+	return p.input[p.pos]
+}
+
+func (p *queryParser) skipSpace() {
+	for !p.eof() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *queryParser) errorAt(pos int, msg string) *QueryError {
+	return &QueryError{Pos: pos + 1, Msg: msg}
+}
+
+// field consumes a field name and its colon when the input continues with
+// one, and leaves the input as is otherwise.
+func (p *queryParser) field() (string, queryField, bool) {
+	end := p.pos
+	for end < len(p.input) && (unicode.IsLetter(p.input[end]) || p.input[end] == '_') {
+		end++
+	}
+	if end == p.pos || end >= len(p.input) || p.input[end] != ':' {
+		return "", queryField{}, false
+	}
+	name := strings.ToLower(string(p.input[p.pos:end]))
+	field, ok := queryFields[name]
+	if !ok {
+		return "", queryField{}, false
+	}
+	p.pos = end + 1
+	return name, field, true
+}
+
+// value consumes a quoted phrase or a bare word.
+func (p *queryParser) value() (string, bool, error) {
+	if p.eof() || p.peek() != '"' {
+		start := p.pos
+		for !p.eof() && !unicode.IsSpace(p.peek()) {
+			p.pos++
+		}
+		return string(p.input[start:p.pos]), false, nil
+	}
+
+	open := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.eof() {
+		r := p.peek()
+		p.pos++
+		switch r {
+		case '"':
+			if !p.eof() && !unicode.IsSpace(p.peek()) {
+				return "", false, p.errorAt(p.pos, "expected a space after the closing quote")
+			}
+			return b.String(), true, nil
+		case '\\':
+			if p.eof() {
+				return "", false, p.errorAt(p.pos-1, "unfinished escape")
+			}
+			b.WriteRune(p.peek())
+			p.pos++
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return "", false, p.errorAt(open, "unterminated quote")
+}
+
+// textClause matches a text field, as a phrase when the value is quoted.
+func textClause(field string) func(string, bool, int) (map[string]interface{}, error) {
+	return func(value string, quoted bool, pos int) (map[string]interface{}, error) {
+		return textMatch(field, value, quoted), nil
+	}
+}
+
+func textMatch(field string, value string, quoted bool) map[string]interface{} {
+	if quoted {
+		return map[string]interface{}{
+			"match_phrase": map[string]interface{}{field: value},
+		}
+	}
+	return map[string]interface{}{
+		"match": map[string]interface{}{
+			field: map[string]interface{}{
+				"query":     value,
+				"operator":  "and",
+				"fuzziness": "AUTO",
+			},
+		},
+	}
+}
+
+func phraseType(quoted bool) string {
+	if quoted {
+		return "phrase"
+	}
+	return "best_fields"
+}
+
+// personClause matches the name of a person credited in any of roles.
+func personClause(roles ...string) func(string, bool, int) (map[string]interface{}, error) {
+	return func(value string, quoted bool, pos int) (map[string]interface{}, error) {
+		should := make([]map[string]interface{}, 0, len(roles))
+		for _, role := range roles {
+			should = append(should, map[string]interface{}{
+				"nested": map[string]interface{}{
+					"path":  role,
+					"query": textMatch(role+".name", value, quoted),
+				},
+			})
+		}
+		if len(should) == 1 {
+			return should[0], nil
+		}
+		return map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should":               should,
+				"minimum_should_match": 1,
+			},
+		}, nil
+	}
+}
+
+// termClause matches a keyword field regardless of case.
+func termClause(field string) func(string, bool, int) (map[string]interface{}, error) {
+	return func(value string, quoted bool, pos int) (map[string]interface{}, error) {
+		return map[string]interface{}{
+			"term": map[string]interface{}{
+				field: map[string]interface{}{
+					"value":            value,
+					"case_insensitive": true,
+				},
+			},
+		}, nil
+	}
+}
+
+func ratingClause(value string, quoted bool, pos int) (map[string]interface{}, error) {
+	bounds, err := parseBounds(value, pos, func(s string) (interface{}, error) {
+		rating, err := strconv.ParseFloat(s, 64)
+		// NaN and infinities can not be sent to Elasticsearch.
+		if err != nil || math.IsNaN(rating) || math.IsInf(rating, 0) {
+			return nil, fmt.Errorf("invalid number")
+		}
+		return rating, nil
+	}, "a number")
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"range": map[string]interface{}{"rating": bounds},
+	}, nil
+}
+
+// yearClause matches release dates by year. Rounding the bounds to the year
+// makes year:1980 and year:..1980 include the whole of 1980.
+func yearClause(value string, quoted bool, pos int) (map[string]interface{}, error) {
+	bounds, err := parseBounds(value, pos, func(s string) (interface{}, error) {
+		year, err := strconv.Atoi(s)
+		if err != nil || year < 1 || year > 9999 {
+			return nil, fmt.Errorf("invalid year")
+		}
+		return fmt.Sprintf("%04d||/y", year), nil
+	}, "a year")
+	if err != nil {
+		return nil, err
+	}
+	bounds["format"] = "yyyy"
+	return map[string]interface{}{
+		"range": map[string]interface{}{"release_date": bounds},
+	}, nil
+}
+
+// parseBounds reads a comparison, a range or a single value into the bounds
+// of a range query. pos is the 1-based position of value in the query.
+func parseBounds(value string, pos int, parse func(string) (interface{}, error), expected string) (map[string]interface{}, error) {
+	bound := func(s string, offset int) (interface{}, error) {
+		v, err := parse(s)
+		if err != nil {
+			return nil, &QueryError{Pos: pos + offset, Msg: fmt.Sprintf("expected %s, got %q", expected, s)}
+		}
+		return v, nil
+	}
+
+	for _, cmp := range []struct{ op, key string }{
+		{">=", "gte"}, {"<=", "lte"}, {">", "gt"}, {"<", "lt"},
+	} {
+		if rest, ok := strings.CutPrefix(value, cmp.op); ok {
+			v, err := bound(rest, len(cmp.op))
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{cmp.key: v}, nil
+		}
+	}
+
+	if from, to, ok := strings.Cut(value, ".."); ok {
+		if from == "" && to == "" {
+			return nil, &QueryError{Pos: pos, Msg: "range without bounds"}
+		}
+		bounds := map[string]interface{}{}
+		if from != "" {
+			v, err := bound(from, 0)
+			if err != nil {
+				return nil, err
+			}
+			bounds["gte"] = v
+		}
+		if to != "" {
+			v, err := bound(to, len([]rune(from))+2)
+			if err != nil {
+				return nil, err
+			}
+			bounds["lte"] = v
+		}
+		return bounds, nil
+	}
+
+	v, err := bound(value, 0)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"gte": v, "lte": v}, nil
+}
@@ -0,0 +1,186 @@
+package filmwork
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type clauses = []map[string]interface{}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		text    string
+		must    clauses
+		filter  clauses
+		mustNot clauses
+	}{
+		{query: "solaris", text: "solaris"},
+		{query: "  solaris   1972 ", text: "solaris 1972"},
+		{query: "Mission: Impossible", text: "Mission: Impossible"},
+		{
+			query: `title:"solaris"`,
+			must:  clauses{{"match_phrase": map[string]interface{}{"title": "solaris"}}},
+		},
+		{
+			query: `title:"say \"hi\" \\o/"`,
+			must:  clauses{{"match_phrase": map[string]interface{}{"title": `say "hi" \o/`}}},
+		},
+		{
+			query: "Description:space",
+			must:  clauses{textMatch("description", "space", false)},
+		},
+		{
+			query: "actor:Banionis",
+			must: clauses{{"nested": map[string]interface{}{
+				"path":  "actors",
+				"query": textMatch("actors.name", "Banionis", false),
+			}}},
+		},
+		{
+			query:  "genre:Drama",
+			filter: clauses{{"term": map[string]interface{}{"genres": map[string]interface{}{"value": "Drama", "case_insensitive": true}}}},
+		},
+		{
+			query:  "rating:>=7.5",
+			filter: clauses{{"range": map[string]interface{}{"rating": map[string]interface{}{"gte": 7.5}}}},
+		},
+		{
+			query: "year:1970..1980",
+			filter: clauses{{"range": map[string]interface{}{"release_date": map[string]interface{}{
+				"gte": "1970||/y", "lte": "1980||/y", "format": "yyyy",
+			}}}},
+		},
+		{
+			query:   "-genre:Horror",
+			mustNot: clauses{{"term": map[string]interface{}{"genres": map[string]interface{}{"value": "Horror", "case_insensitive": true}}}},
+		},
+		{
+			query: `solaris -"open space"`,
+			text:  "solaris",
+			mustNot: clauses{{"multi_match": map[string]interface{}{
+				"query":  "open space",
+				"fields": []string{"title", "description"},
+				"type":   "phrase",
+			}}},
+		},
+		{query: "solaris - 1972", text: "solaris - 1972"},
+		{
+			query:  "солярис rating:<8",
+			text:   "солярис",
+			filter: clauses{{"range": map[string]interface{}{"rating": map[string]interface{}{"lt": 8.0}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) failed: %v", tt.query, err)
+			}
+			if q.Text != tt.text {
+				t.Errorf("Text = %q, want %q", q.Text, tt.text)
+			}
+			if !reflect.DeepEqual(q.must, tt.must) {
+				t.Errorf("must = %v, want %v", q.must, tt.must)
+			}
+			if !reflect.DeepEqual(q.filter, tt.filter) {
+				t.Errorf("filter = %v, want %v", q.filter, tt.filter)
+			}
+			if !reflect.DeepEqual(q.mustNot, tt.mustNot) {
+				t.Errorf("mustNot = %v, want %v", q.mustNot, tt.mustNot)
+			}
+			if free := tt.must == nil && tt.filter == nil && tt.mustNot == nil; q.IsFreeText() != free {
+				t.Errorf("IsFreeText() = %v, want %v", q.IsFreeText(), free)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{query: "genre:", pos: 7, msg: "missing value of genre"},
+		{query: "solaris genre: drama", pos: 15, msg: "missing value of genre"},
+		{query: `title:"solaris`, pos: 7, msg: "unterminated quote"},
+		{query: `title:"solaris"1972`, pos: 16, msg: "expected a space after the closing quote"},
+		{query: `title:"solaris\`, pos: 15, msg: "unfinished escape"},
+		{query: "rating:high", pos: 8, msg: `expected a number, got "high"`},
+		{query: "rating:>=high", pos: 10, msg: `expected a number, got "high"`},
+		{query: "rating:7..high", pos: 11, msg: `expected a number, got "high"`},
+		{query: "rating:NaN", pos: 8, msg: `expected a number, got "NaN"`},
+		{query: "rating:>Inf", pos: 9, msg: `expected a number, got "Inf"`},
+		{query: "rating:-infinity..", pos: 8, msg: `expected a number, got "-infinity"`},
+		{query: "rating:1..1e999", pos: 11, msg: `expected a number, got "1e999"`},
+		{query: "year:..", pos: 6, msg: "range without bounds"},
+		{query: "year:10000", pos: 6, msg: `expected a year, got "10000"`},
+		{query: "солярис year:197x", pos: 14, msg: `expected a year, got "197x"`},
+		{query: "-year:1970..19x0", pos: 13, msg: `expected a year, got "19x0"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			var queryErr *QueryError
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("ParseQuery(%q) error = %v, want a *QueryError", tt.query, err)
+			}
+			if queryErr.Pos != tt.pos || queryErr.Msg != tt.msg {
+				t.Errorf("error at %d %q, want at %d %q", queryErr.Pos, queryErr.Msg, tt.pos, tt.msg)
+			}
+		})
+	}
+}
+
+func TestParseBounds(t *testing.T) {
+	parseInt := func(s string) (interface{}, error) {
+		return strconv.Atoi(s)
+	}
+	tests := []struct {
+		value  string
+		bounds map[string]interface{}
+		pos    int
+	}{
+		{value: "5", bounds: map[string]interface{}{"gte": 5, "lte": 5}},
+		{value: ">5", bounds: map[string]interface{}{"gt": 5}},
+		{value: ">=5", bounds: map[string]interface{}{"gte": 5}},
+		{value: "<5", bounds: map[string]interface{}{"lt": 5}},
+		{value: "<=5", bounds: map[string]interface{}{"lte": 5}},
+		{value: "1..5", bounds: map[string]interface{}{"gte": 1, "lte": 5}},
+		{value: "1..", bounds: map[string]interface{}{"gte": 1}},
+		{value: "..5", bounds: map[string]interface{}{"lte": 5}},
+		{value: "", pos: 10},
+		{value: "x", pos: 10},
+		{value: ">=", pos: 12},
+		{value: "<x", pos: 11},
+		{value: "..", pos: 10},
+		{value: "x..5", pos: 10},
+		{value: "1..x", pos: 13},
+		{value: "10..5x", pos: 14},
+		{value: "1...5", pos: 13},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			bounds, err := parseBounds(tt.value, 10, parseInt, "a number")
+			if tt.bounds != nil {
+				if err != nil {
+					t.Fatalf("parseBounds(%q) failed: %v", tt.value, err)
+				}
+				if !reflect.DeepEqual(bounds, tt.bounds) {
+					t.Errorf("bounds = %v, want %v", bounds, tt.bounds)
+				}
+				return
+			}
+			var queryErr *QueryError
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("parseBounds(%q) error = %v, want a *QueryError", tt.value, err)
+			}
+			if queryErr.Pos != tt.pos {
+				t.Errorf("error at %d, want at %d", queryErr.Pos, tt.pos)
+			}
+		})
+	}
+}
@@ -8,31 +8,70 @@ import (
 	"encoding/json"
 	"github.com/elastic/go-elasticsearch/v9"
 	"io"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v9/esapi"
+
+	"async-api/internal/config"
+	"async-api/internal/domain/person"
+	"async-api/internal/index"
+	"async-api/pkg/translit"
 )
 
 type Repository interface {
 	GetByID(ctx context.Context, filmworkId string) (*Filmwork, error)
-	GetAll(ctx context.Context, page int, size int) ([]*BaseFilmwork, error)
+	// Version looks up the version of the filmwork document without its
+	// source.
+	Version(ctx context.Context, filmworkId string) (*index.Version, error)
+	// GetByIDs looks the filmworks up with a single multi-get. Ids that do
+	// not exist are absent from the result.
+	GetByIDs(ctx context.Context, ids []string) (map[string]*Filmwork, error)
+	// GetSource returns the filmwork document limited to the includes source
+	// fields, or the whole document when includes is empty.
+	GetSource(ctx context.Context, filmworkId string, includes []string) (map[string]json.RawMessage, error)
+	// GetAll lists the filmworks matching persons in index order, or by
+	// editorial rating when sort is "rating" or "-rating".
+	GetAll(ctx context.Context, page int, size int, sort string, persons PersonFilter) ([]*BaseFilmwork, error)
 	Search(ctx context.Context, q string, limit int) ([]*BaseFilmwork, error)
+	// Suggest returns a spelling correction of q taken from titles and cast
+	// names, or an empty string when there is none.
+	Suggest(ctx context.Context, q string) (string, error)
+	// Recommend ranks the filmworks open to audience by how well they match
+	// profile, leaving out the exclude ids.
+	Recommend(ctx context.Context, profile Profile, exclude []string, audience Audience, size int) ([]*BaseFilmwork, error)
+	// Releases lists the filmworks released from from to to inclusive, by
+	// release date. Either bound may be nil.
+	Releases(ctx context.Context, from *Date, to *Date, page int, size int) ([]*DatedFilmwork, error)
+	// Anniversaries lists the filmworks released on the month and day of date
+	// in earlier years, oldest first.
+	Anniversaries(ctx context.Context, date Date, page int, size int) ([]*DatedFilmwork, error)
+	// Discover lists the filmworks matching filter in a random order that
+	// is the same for the same seed.
+	Discover(ctx context.Context, seed int64, filter DiscoverFilter, page int, size int) ([]*BaseFilmwork, error)
 }
 
 type filmworkRepository struct {
-	es *elasticsearch.Client
+	es       *elasticsearch.Client
+	indices  config.ElasticIndicesConfig
+	timeouts config.ElasticTimeoutsConfig
 }
 
-func NewFilmworkRepository(es *elasticsearch.Client) Repository {
-	return &filmworkRepository{es: es}
+func NewFilmworkRepository(es *elasticsearch.Client, cfg config.ElasticConfig) Repository {
+	return &filmworkRepository{es: es, indices: cfg.Indices, timeouts: cfg.Timeouts}
 }
 
 func (r *filmworkRepository) GetByID(ctx context.Context, filmworkId string) (*Filmwork, error) {
 	req := esapi.GetRequest{
-		Index:      "movies",
+		Index:      r.indices.Movies,
 		DocumentID: filmworkId,
 	}
 
-	resp, err := req.Do(ctx, r.es)
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
 	if err != nil {
 		return nil, fmt.Errorf("Elasticsearch request error: %w", err)
 	}
@@ -56,7 +95,39 @@ func (r *filmworkRepository) GetByID(ctx context.Context, filmworkId string) (*F
 	return &response.Source, nil
 }
 
-func (r *filmworkRepository) GetAll(ctx context.Context, page int, size int) ([]*BaseFilmwork, error) {
+func (r *filmworkRepository) Version(ctx context.Context, filmworkId string) (*index.Version, error) {
+	req := esapi.GetRequest{
+		Index:          r.indices.Movies,
+		DocumentID:     filmworkId,
+		SourceIncludes: index.VersionFields,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		if resp.StatusCode == 404 {
+			return nil, fmt.Errorf("Filmwork with ID '%s' not found", filmworkId)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Elasticsearch error [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response index.VersionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	return response.Version(), nil
+}
+
+func (r *filmworkRepository) GetAll(ctx context.Context, page int, size int, sort string, persons PersonFilter) ([]*BaseFilmwork, error) {
 	offset := (page - 1) * size
 	query := map[string]interface{}{
 		"query": map[string]interface{}{
@@ -65,6 +136,15 @@ func (r *filmworkRepository) GetAll(ctx context.Context, page int, size int) ([]
 		"from": offset,
 		"size": size,
 	}
+	if !persons.IsZero() {
+		query["query"] = map[string]interface{}{"bool": PersonFilterQuery(persons)}
+	}
+	switch sort {
+	case "rating":
+		query["sort"] = []map[string]interface{}{{"rating": "asc"}, {"id": "asc"}}
+	case "-rating":
+		query["sort"] = []map[string]interface{}{{"rating": "desc"}, {"id": "asc"}}
+	}
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(query); err != nil {
@@ -72,11 +152,14 @@ func (r *filmworkRepository) GetAll(ctx context.Context, page int, size int) ([]
 	}
 
 	req := esapi.SearchRequest{
-		Index: []string{"movies"},
+		Index: []string{r.indices.Movies},
 		Body:  &buf,
 	}
 
-	resp, err := req.Do(ctx, r.es)
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
 	if err != nil {
 		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
 	}
@@ -115,29 +198,66 @@ func (r *filmworkRepository) GetAll(ctx context.Context, page int, size int) ([]
 	return filmworks, nil
 }
 
-func (r *filmworkRepository) Search(ctx context.Context, q string, limit int) ([]*BaseFilmwork, error) {
-	var queryBody map[string]interface{}
+// transliteratedBoost is the weight of a match on a transliterated variant of
+// the query relative to a match on the query as typed.
+const transliteratedBoost = 0.5
+
+// SearchQuery returns the query clause /filmworks/search runs for q, which
+// may use the query language described on Query. Malformed queries fail with
+// a *QueryError.
+func SearchQuery(q string) (map[string]interface{}, error) {
+	query, err := ParseQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	return query.Clause(), nil
+}
 
+// textQuery returns the query clause for free text q. An empty q matches
+// every filmwork. Besides q itself it matches the transliterations of q,
+// ranking them below matches in the script of q.
+func textQuery(q string) map[string]interface{} {
 	if q == "" {
-		queryBody = map[string]interface{}{
-			"query": map[string]interface{}{
-				"match_all": map[string]interface{}{},
-			},
-			"size": limit,
-		}
-	} else {
-		queryBody = map[string]interface{}{
-			"query": map[string]interface{}{
-				"multi_match": map[string]interface{}{
-					"query":     q,
-					"fields":    []string{"title", "description"},
-					"type":      "best_fields",
-					"fuzziness": "AUTO",
-				},
-			},
-			"size": limit,
+		return map[string]interface{}{
+			"match_all": map[string]interface{}{},
 		}
 	}
+	should := []map[string]interface{}{titleMatch(q, 1)}
+	if normalized := translit.Normalize(q); normalized != q {
+		should = append(should, titleMatch(normalized, 1))
+	}
+	for _, variant := range translit.Variants(q) {
+		should = append(should, titleMatch(variant, transliteratedBoost))
+	}
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should":               should,
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+func titleMatch(q string, boost float64) map[string]interface{} {
+	return map[string]interface{}{
+		"multi_match": map[string]interface{}{
+			"query":     q,
+			"fields":    []string{"title", "description"},
+			"type":      "best_fields",
+			"fuzziness": "AUTO",
+			"boost":     boost,
+		},
+	}
+}
+
+func (r *filmworkRepository) Search(ctx context.Context, q string, limit int) ([]*BaseFilmwork, error) {
+	query, err := SearchQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	queryBody := map[string]interface{}{
+		"query": query,
+		"size":  limit,
+	}
 
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(queryBody); err != nil {
@@ -145,11 +265,14 @@ func (r *filmworkRepository) Search(ctx context.Context, q string, limit int) ([
 	}
 
 	req := esapi.SearchRequest{
-		Index: []string{"movies"},
+		Index: []string{r.indices.Movies},
 		Body:  &buf,
 	}
 
-	resp, err := req.Do(ctx, r.es)
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
 	if err != nil {
 		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
 	}
@@ -192,3 +315,529 @@ func (r *filmworkRepository) Search(ctx context.Context, q string, limit int) ([
 
 	return filmworks, nil
 }
+
+// AudienceQuery returns the bool clauses that keep a query within audience.
+func AudienceQuery(audience Audience) map[string]interface{} {
+	query := map[string]interface{}{}
+	if i := slices.Index(AgeRatings, audience.MaxAgeRating); i >= 0 {
+		query["filter"] = []map[string]interface{}{
+			{"terms": map[string]interface{}{"age_rating": AgeRatings[:i+1]}},
+		}
+	}
+	if !audience.Subscriber {
+		query["must_not"] = []map[string]interface{}{
+			{"term": map[string]interface{}{"access_type": "subscription"}},
+		}
+	}
+	return query
+}
+
+// PersonFilterQuery returns the bool clauses that keep a query to the
+// filmworks matching persons: one nested clause per person, all of which
+// must match for With and none for Without.
+func PersonFilterQuery(persons PersonFilter) map[string]interface{} {
+	query := map[string]interface{}{}
+	if len(persons.With) > 0 {
+		filter := make([]map[string]interface{}, 0, len(persons.With))
+		for _, p := range persons.With {
+			filter = append(filter, personRefQuery(p))
+		}
+		query["filter"] = filter
+	}
+	if len(persons.Without) > 0 {
+		mustNot := make([]map[string]interface{}, 0, len(persons.Without))
+		for _, p := range persons.Without {
+			mustNot = append(mustNot, personRefQuery(p))
+		}
+		query["must_not"] = mustNot
+	}
+	return query
+}
+
+// personRefQuery matches the filmworks p is credited on in p.Role, or in any
+// role when it is empty.
+func personRefQuery(p PersonRef) map[string]interface{} {
+	roles := person.Roles
+	if p.Role != "" {
+		roles = []string{p.Role}
+	}
+	should := make([]map[string]interface{}, 0, len(roles))
+	for _, role := range roles {
+		path := role + "s"
+		var match map[string]interface{}
+		if p.ID != "" {
+			match = map[string]interface{}{"term": map[string]interface{}{path + ".id": p.ID}}
+		} else {
+			match = map[string]interface{}{
+				"match": map[string]interface{}{
+					path + ".name": map[string]interface{}{"query": p.Name, "operator": "and"},
+				},
+			}
+		}
+		should = append(should, map[string]interface{}{
+			"nested": map[string]interface{}{"path": path, "query": match},
+		})
+	}
+	if len(should) == 1 {
+		return should[0]
+	}
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should":               should,
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+func (r *filmworkRepository) Discover(ctx context.Context, seed int64, filter DiscoverFilter, page int, size int) ([]*BaseFilmwork, error) {
+	boolQuery := AudienceQuery(filter.Audience)
+	clauses, _ := boolQuery["filter"].([]map[string]interface{})
+	if filter.Genre != "" {
+		clauses = append(clauses, map[string]interface{}{
+			"term": map[string]interface{}{"genres": map[string]interface{}{"value": filter.Genre, "case_insensitive": true}},
+		})
+	}
+	if filter.Type != "" {
+		clauses = append(clauses, map[string]interface{}{"term": map[string]interface{}{"type": filter.Type}})
+	}
+	if filter.MinRating != nil {
+		clauses = append(clauses, map[string]interface{}{
+			"range": map[string]interface{}{"rating": map[string]interface{}{"gte": *filter.MinRating}},
+		})
+	}
+	if len(clauses) > 0 {
+		boolQuery["filter"] = clauses
+	}
+
+	// Scores are derived from the seed and the filmwork id, which unlike the
+	// default _seq_no survives reindexing, so a seed keeps its order.
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"function_score": map[string]interface{}{
+				"query":        map[string]interface{}{"bool": boolQuery},
+				"random_score": map[string]interface{}{"seed": seed, "field": "id"},
+				"boost_mode":   "replace",
+			},
+		},
+		"from": (page - 1) * size,
+		"size": size,
+		"sort": []interface{}{"_score", map[string]interface{}{"id": "asc"}},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, fmt.Errorf("request coding error: %w", err)
+	}
+
+	req := esapi.SearchRequest{
+		Index: []string{r.indices.Movies},
+		Body:  &buf,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error Elasticsearch [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		Hits struct {
+			Hits []struct {
+				Source struct {
+					ID     string  `json:"id"`
+					Title  string  `json:"title"`
+					Rating float32 `json:"rating"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	filmworks := make([]*BaseFilmwork, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		filmworks = append(filmworks, &BaseFilmwork{
+			ID:     hit.Source.ID,
+			Title:  hit.Source.Title,
+			Rating: hit.Source.Rating,
+		})
+	}
+
+	return filmworks, nil
+}
+
+// Recommendation boosts of a matching director or actor relative to a
+// matching genre of the same profile weight.
+const (
+	directorBoost = 1.5
+	actorBoost    = 1.0
+)
+
+func (r *filmworkRepository) Recommend(ctx context.Context, profile Profile, exclude []string, audience Audience, size int) ([]*BaseFilmwork, error) {
+	var should []map[string]interface{}
+	var functions []map[string]interface{}
+	for _, genre := range sortedKeys(profile.Genres) {
+		clause := map[string]interface{}{"term": map[string]interface{}{"genres": genre}}
+		should = append(should, clause)
+		functions = append(functions, map[string]interface{}{"filter": clause, "weight": profile.Genres[genre]})
+	}
+	for _, role := range []struct {
+		path    string
+		weights map[string]float64
+		boost   float64
+	}{
+		{"directors", profile.Directors, directorBoost},
+		{"actors", profile.Actors, actorBoost},
+	} {
+		for _, id := range sortedKeys(role.weights) {
+			clause := map[string]interface{}{
+				"nested": map[string]interface{}{
+					"path":  role.path,
+					"query": map[string]interface{}{"term": map[string]interface{}{role.path + ".id": id}},
+				},
+			}
+			should = append(should, clause)
+			functions = append(functions, map[string]interface{}{"filter": clause, "weight": role.weights[id] * role.boost})
+		}
+	}
+	// The editorial rating breaks ties between equally matching filmworks and
+	// ranks the catalogue for users without a profile yet.
+	functions = append(functions, map[string]interface{}{
+		"field_value_factor": map[string]interface{}{
+			"field":    "rating",
+			"modifier": "log1p",
+			"missing":  0,
+		},
+	})
+
+	boolQuery := AudienceQuery(audience)
+	if len(should) > 0 {
+		boolQuery["should"] = should
+		boolQuery["minimum_should_match"] = 1
+	}
+	if len(exclude) > 0 {
+		mustNot, _ := boolQuery["must_not"].([]map[string]interface{})
+		boolQuery["must_not"] = append(mustNot, map[string]interface{}{
+			"ids": map[string]interface{}{"values": exclude},
+		})
+	}
+
+	queryBody := map[string]interface{}{
+		"query": map[string]interface{}{
+			"function_score": map[string]interface{}{
+				"query":      map[string]interface{}{"bool": boolQuery},
+				"functions":  functions,
+				"score_mode": "sum",
+				"boost_mode": "replace",
+			},
+		},
+		"sort": []interface{}{"_score", map[string]interface{}{"id": "asc"}},
+		"size": size,
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(queryBody); err != nil {
+		return nil, fmt.Errorf("request coding error: %w", err)
+	}
+
+	req := esapi.SearchRequest{
+		Index: []string{r.indices.Movies},
+		Body:  &buf,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error Elasticsearch [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		Hits struct {
+			Hits []struct {
+				Source struct {
+					ID     string  `json:"id"`
+					Title  string  `json:"title"`
+					Rating float32 `json:"rating"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	filmworks := make([]*BaseFilmwork, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		filmworks = append(filmworks, &BaseFilmwork{
+			ID:     hit.Source.ID,
+			Title:  hit.Source.Title,
+			Rating: hit.Source.Rating,
+		})
+	}
+
+	return filmworks, nil
+}
+
+// sortedKeys returns the keys of weights in a stable order, so that equal
+// profiles produce identical queries.
+func sortedKeys(weights map[string]float64) []string {
+	return slices.Sorted(maps.Keys(weights))
+}
+
+// suggestFields are the fields spelling corrections of search queries are
+// taken from.
+var suggestFields = []string{"title", "actors_names", "directors_names", "writers_names"}
+
+func (r *filmworkRepository) Suggest(ctx context.Context, q string) (string, error) {
+	queryBody := map[string]interface{}{
+		"size":    0,
+		"suggest": index.PhraseSuggest(q, suggestFields),
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(queryBody); err != nil {
+		return "", fmt.Errorf("request coding error: %w", err)
+	}
+
+	req := esapi.SearchRequest{
+		Index: []string{r.indices.Movies},
+		Body:  &buf,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return "", fmt.Errorf("Elasticsearch search error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("error Elasticsearch [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		Suggest index.Suggestions `json:"suggest"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("response parsing error: %w", err)
+	}
+
+	return response.Suggest.Best(), nil
+}
+
+func (r *filmworkRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*Filmwork, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]interface{}{"ids": ids}); err != nil {
+		return nil, fmt.Errorf("request coding error: %w", err)
+	}
+
+	req := esapi.MgetRequest{
+		Index: r.indices.Movies,
+		Body:  &buf,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Elasticsearch error [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		Docs []struct {
+			ID     string   `json:"_id"`
+			Found  bool     `json:"found"`
+			Source Filmwork `json:"_source"`
+		} `json:"docs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	filmworks := make(map[string]*Filmwork, len(response.Docs))
+	for _, doc := range response.Docs {
+		if !doc.Found {
+			continue
+		}
+		source := doc.Source
+		filmworks[doc.ID] = &source
+	}
+
+	return filmworks, nil
+}
+
+func (r *filmworkRepository) GetSource(ctx context.Context, filmworkId string, includes []string) (map[string]json.RawMessage, error) {
+	req := esapi.GetSourceRequest{
+		Index:          r.indices.Movies,
+		DocumentID:     filmworkId,
+		SourceIncludes: includes,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		if resp.StatusCode == 404 {
+			return nil, fmt.Errorf("Filmwork with ID '%s' not found", filmworkId)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Elasticsearch error [%d]: %s", resp.StatusCode, body)
+	}
+
+	var source map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&source); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	return source, nil
+}
+
+func (r *filmworkRepository) Releases(ctx context.Context, from *Date, to *Date, page int, size int) ([]*DatedFilmwork, error) {
+	bounds := map[string]interface{}{"format": "yyyy-MM-dd"}
+	if from != nil {
+		bounds["gte"] = from.String()
+	}
+	if to != nil {
+		bounds["lte"] = to.String()
+	}
+	return r.searchDated(ctx, map[string]interface{}{
+		"query": map[string]interface{}{
+			"range": map[string]interface{}{"release_date": bounds},
+		},
+		"from": (page - 1) * size,
+		"size": size,
+		"sort": []map[string]interface{}{{"release_date": "asc"}, {"id": "asc"}},
+	})
+}
+
+// releaseMonthDay is a runtime field holding the month and day of the release
+// date as MMDD, so that anniversaries can be matched across years.
+var releaseMonthDay = map[string]interface{}{
+	"release_month_day": map[string]interface{}{
+		"type": "long",
+		"script": map[string]interface{}{
+			"source": "if (doc['release_date'].size() != 0) { def d = doc['release_date'].value; emit(d.getMonthValue() * 100 + d.getDayOfMonth()); }",
+		},
+	},
+}
+
+func (r *filmworkRepository) Anniversaries(ctx context.Context, date Date, page int, size int) ([]*DatedFilmwork, error) {
+	days := []int{int(date.Month())*100 + date.Day()}
+	// Filmworks released on 29 February celebrate on the 28th in other years.
+	if date.Month() == time.February && date.Day() == 28 && date.AddDate(0, 0, 1).Month() == time.March {
+		days = append(days, 229)
+	}
+	filmworks, err := r.searchDated(ctx, map[string]interface{}{
+		"runtime_mappings": releaseMonthDay,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []map[string]interface{}{
+					{"terms": map[string]interface{}{"release_month_day": days}},
+					{"range": map[string]interface{}{"release_date": map[string]interface{}{"lt": date.String(), "format": "yyyy-MM-dd"}}},
+				},
+			},
+		},
+		"from": (page - 1) * size,
+		"size": size,
+		"sort": []map[string]interface{}{{"release_date": "asc"}, {"id": "asc"}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range filmworks {
+		f.Years = date.Year() - f.ReleaseDate.Year()
+	}
+	return filmworks, nil
+}
+
+func (r *filmworkRepository) searchDated(ctx context.Context, query map[string]interface{}) ([]*DatedFilmwork, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, fmt.Errorf("request coding error: %w", err)
+	}
+
+	req := esapi.SearchRequest{
+		Index: []string{r.indices.Movies},
+		Body:  &buf,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error Elasticsearch [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		Hits struct {
+			Hits []struct {
+				Source struct {
+					ID          string  `json:"id"`
+					Title       string  `json:"title"`
+					Rating      float32 `json:"rating"`
+					ReleaseDate *Date   `json:"release_date"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	filmworks := make([]*DatedFilmwork, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		filmworks = append(filmworks, &DatedFilmwork{
+			BaseFilmwork: BaseFilmwork{
+				ID:     hit.Source.ID,
+				Title:  hit.Source.Title,
+				Rating: hit.Source.Rating,
+			},
+			ReleaseDate: hit.Source.ReleaseDate,
+		})
+	}
+
+	return filmworks, nil
+}
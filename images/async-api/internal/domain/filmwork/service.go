@@ -1,23 +1,113 @@
 package filmwork
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"async-api/internal/domain/activity"
+	"async-api/internal/domain/bookmark"
+	"async-api/internal/domain/genre"
+	"async-api/internal/domain/person"
+	"async-api/internal/domain/rating"
+)
+
+// ErrRatingsUnavailable is returned for user_rating sorts while no UGC store
+// is configured.
+var ErrRatingsUnavailable = errors.New("user ratings are not available")
+
+// ErrRecommendationsUnavailable is returned by Recommend while no UGC store is
+// configured.
+var ErrRecommendationsUnavailable = errors.New("recommendations are not available")
+
+// ErrPersonFilterSort is returned for user_rating sorts combined with a
+// person filter: the vote ranking comes from the UGC store, which knows
+// nothing of casts.
+var ErrPersonFilterSort = errors.New("user_rating sorts can not be combined with person filters")
+
+const (
+	// minLikedScore is the lowest vote that counts as liking a filmwork.
+	minLikedScore = 7
+	// maxProfileSeeds caps the bookmarked and liked filmworks a profile is
+	// built from.
+	maxProfileSeeds = 100
+	// Sizes of the profile: the strongest genres, directors and actors kept.
+	profileGenres    = 5
+	profileDirectors = 5
+	profileActors    = 10
 )
 
 type FilmworkService interface {
 	GetByID(ctx context.Context, id string) (*Filmwork, error)
-	GetAll(ctx context.Context, page int, size int) ([]*BaseFilmwork, error)
+	// Revision returns what identifies the filmwork GetByID returns.
+	Revision(ctx context.Context, id string) (*Revision, error)
+	GetByIDs(ctx context.Context, ids []string) (*FilmworkBatch, error)
+	// RecordView counts a view of the filmwork page towards the trending
+	// rankings; lookups do not count views by themselves. Failures are
+	// logged.
+	RecordView(ctx context.Context, id string)
+	// GetByIDView returns the filmwork reduced to fields, with the expand
+	// fields resolved into genre and person objects.
+	GetByIDView(ctx context.Context, id string, fields []string, expand []string) (map[string]interface{}, error)
+	// GetAll lists the filmworks matching persons. Sorting by user_rating
+	// only lists filmworks that have received votes.
+	GetAll(ctx context.Context, page int, size int, sort string, persons PersonFilter) ([]*BaseFilmwork, error)
 	Search(ctx context.Context, query string, limit int) ([]*BaseFilmwork, error)
+	// SearchWithSuggestion is Search that suggests a spelling correction when
+	// nothing is found and, with autocorrect, searches for it instead.
+	SearchWithSuggestion(ctx context.Context, query string, limit int, autocorrect bool) (*FilmworkSearch, error)
+	// Recommend suggests filmworks to userID based on the genres and cast of
+	// the filmworks they bookmarked or scored highly. Those filmworks are
+	// never recommended themselves.
+	Recommend(ctx context.Context, userID string, audience Audience, size int) ([]*BaseFilmwork, error)
+	// Trending lists the filmworks with the most decayed activity (views,
+	// votes and bookmarks) over the last window; zero selects the default.
+	Trending(ctx context.Context, window time.Duration, page int, size int) ([]*BaseFilmwork, error)
+	// Popular is Trending over the whole activity retention with a slower
+	// decay.
+	Popular(ctx context.Context, page int, size int) ([]*BaseFilmwork, error)
+	// Releases lists the filmworks released from from to to inclusive, by
+	// release date; either bound may be nil.
+	Releases(ctx context.Context, from *Date, to *Date, page int, size int) ([]*DatedFilmwork, error)
+	// Upcoming lists the filmworks released after today, soonest first.
+	Upcoming(ctx context.Context, page int, size int) ([]*DatedFilmwork, error)
+	// Anniversaries lists the filmworks released on the month and day of date
+	// in earlier years.
+	Anniversaries(ctx context.Context, date Date, page int, size int) ([]*DatedFilmwork, error)
+	// Discover returns a page of the filmworks matching filter in a random
+	// order. A nil seed starts a new order; the seed of the result continues
+	// it.
+	Discover(ctx context.Context, seed *int64, filter DiscoverFilter, page int, size int) (*Discovery, error)
 }
 
 type filmworkServiceImpl struct {
-	repo Repository
+	repo         Repository
+	personRepo   person.Repository
+	genreRepo    genre.Repository
+	ratingRepo   rating.Repository
+	bookmarkRepo bookmark.Repository
+	activityRepo activity.Repository
 }
 
-func NewFilmworkService(repo Repository) FilmworkService {
+// NewFilmworkService builds the service; ratingRepo and bookmarkRepo may be
+// nil, in which case responses carry no audience ratings and recommendations
+// are unavailable.
+func NewFilmworkService(repo Repository, personRepo person.Repository, genreRepo genre.Repository, ratingRepo rating.Repository, bookmarkRepo bookmark.Repository, activityRepo activity.Repository) FilmworkService {
 	return &filmworkServiceImpl{
-		repo: repo,
+		repo:         repo,
+		personRepo:   personRepo,
+		genreRepo:    genreRepo,
+		ratingRepo:   ratingRepo,
+		bookmarkRepo: bookmarkRepo,
+		activityRepo: activityRepo,
 	}
 }
 
@@ -26,14 +116,72 @@ func (s *filmworkServiceImpl) GetByID(ctx context.Context, id string) (*Filmwork
 	if err != nil {
 		return nil, fmt.Errorf("failed to get filmwork: %w", err)
 	}
+	ratings := s.ratings(ctx, []string{f.ID})
+	f.UserRating, f.VotesCount = ratings[f.ID].UserRating, ratings[f.ID].VotesCount
 	return f, nil
 }
 
-func (s *filmworkServiceImpl) GetAll(ctx context.Context, page int, size int) ([]*BaseFilmwork, error) {
-	filmworks, err := s.repo.GetAll(ctx, page, size)
+func (s *filmworkServiceImpl) Revision(ctx context.Context, id string) (*Revision, error) {
+	v, err := s.repo.Version(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filmwork: %w", err)
+	}
+	revision := &Revision{Version: *v}
+	if s.ratingRepo != nil {
+		r := s.ratings(ctx, []string{id})[id]
+		revision.Rating = &r
+	}
+	return revision, nil
+}
+
+func (s *filmworkServiceImpl) GetAll(ctx context.Context, page int, size int, sort string, persons PersonFilter) ([]*BaseFilmwork, error) {
+	if sort == "user_rating" || sort == "-user_rating" {
+		if !persons.IsZero() {
+			return nil, ErrPersonFilterSort
+		}
+		return s.getAllByUserRating(ctx, page, size, sort == "user_rating")
+	}
+	filmworks, err := s.repo.GetAll(ctx, page, size, sort, persons)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get filmworks: %w", err)
 	}
+	s.applyRatings(ctx, filmworks)
+	return filmworks, nil
+}
+
+// getAllByUserRating pages through the voted filmworks in rating order and
+// hydrates them from the catalogue.
+func (s *filmworkServiceImpl) getAllByUserRating(ctx context.Context, page int, size int, ascending bool) ([]*BaseFilmwork, error) {
+	if s.ratingRepo == nil {
+		return nil, ErrRatingsUnavailable
+	}
+	ranked, err := s.ratingRepo.Ranked(ctx, ascending, (page-1)*size, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank filmworks: %w", err)
+	}
+	ids := make([]string, 0, len(ranked))
+	for _, r := range ranked {
+		ids = append(ids, r.ID)
+	}
+	found, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filmworks: %w", err)
+	}
+
+	filmworks := make([]*BaseFilmwork, 0, len(ranked))
+	for _, r := range ranked {
+		f, ok := found[r.ID]
+		if !ok {
+			continue
+		}
+		filmworks = append(filmworks, &BaseFilmwork{
+			ID:         f.ID,
+			Title:      f.Title,
+			Rating:     f.Rating,
+			UserRating: r.Rating.UserRating,
+			VotesCount: r.Rating.VotesCount,
+		})
+	}
 	return filmworks, nil
 }
 
@@ -42,5 +190,405 @@ func (s *filmworkServiceImpl) Search(ctx context.Context, query string, limit in
 	if err != nil {
 		return nil, fmt.Errorf("failed to search filmworks: %w", err)
 	}
+	s.applyRatings(ctx, filmworks)
+	return filmworks, nil
+}
+
+func (s *filmworkServiceImpl) Recommend(ctx context.Context, userID string, audience Audience, size int) ([]*BaseFilmwork, error) {
+	if s.ratingRepo == nil || s.bookmarkRepo == nil {
+		return nil, ErrRecommendationsUnavailable
+	}
+	bookmarks, err := s.bookmarkRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookmarks: %w", err)
+	}
+	scores, err := s.ratingRepo.GetUserScores(ctx, userID, minLikedScore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user scores: %w", err)
+	}
+
+	// A bookmark weighs as much as the top score; liked filmworks weigh from
+	// 0.4 for a 7 up to 1 for a 10.
+	seeds := make(map[string]float64, len(bookmarks)+len(scores))
+	for _, id := range bookmarks {
+		seeds[id] += 1
+	}
+	for id, score := range scores {
+		seeds[id] += float64(score-5) / 5
+	}
+	exclude := slices.Sorted(maps.Keys(seeds))
+
+	profile, err := s.profile(ctx, seeds)
+	if err != nil {
+		return nil, err
+	}
+	filmworks, err := s.repo.Recommend(ctx, profile, exclude, audience, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recommend filmworks: %w", err)
+	}
+	s.applyRatings(ctx, filmworks)
+	return filmworks, nil
+}
+
+// profile sums the seed weights over the genres, directors and actors of the
+// seed filmworks and keeps the strongest of each.
+func (s *filmworkServiceImpl) profile(ctx context.Context, seeds map[string]float64) (Profile, error) {
+	ids := byWeight(seeds)
+	if len(ids) > maxProfileSeeds {
+		ids = ids[:maxProfileSeeds]
+	}
+
+	profile := Profile{
+		Genres:    map[string]float64{},
+		Directors: map[string]float64{},
+		Actors:    map[string]float64{},
+	}
+	if len(ids) == 0 {
+		return profile, nil
+	}
+	found, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return Profile{}, fmt.Errorf("failed to get filmworks: %w", err)
+	}
+	for id, f := range found {
+		weight := seeds[id]
+		for _, g := range f.Genres {
+			profile.Genres[g] += weight
+		}
+		for _, p := range f.Directors {
+			profile.Directors[p.ID] += weight
+		}
+		for _, p := range f.Actors {
+			profile.Actors[p.ID] += weight
+		}
+	}
+	profile.Genres = strongest(profile.Genres, profileGenres)
+	profile.Directors = strongest(profile.Directors, profileDirectors)
+	profile.Actors = strongest(profile.Actors, profileActors)
+	return profile, nil
+}
+
+// strongest keeps the n heaviest entries of weights.
+func strongest(weights map[string]float64, n int) map[string]float64 {
+	if len(weights) <= n {
+		return weights
+	}
+	kept := make(map[string]float64, n)
+	for _, key := range byWeight(weights)[:n] {
+		kept[key] = weights[key]
+	}
+	return kept
+}
+
+// byWeight returns the keys of weights heaviest first, breaking ties by key.
+func byWeight(weights map[string]float64) []string {
+	return slices.SortedFunc(maps.Keys(weights), func(a, b string) int {
+		if c := cmp.Compare(weights[b], weights[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+}
+
+func (s *filmworkServiceImpl) Trending(ctx context.Context, window time.Duration, page int, size int) ([]*BaseFilmwork, error) {
+	scored, err := s.activityRepo.Trending(ctx, window, (page-1)*size, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank trending filmworks: %w", err)
+	}
+	return s.hydrate(ctx, scored)
+}
+
+func (s *filmworkServiceImpl) Popular(ctx context.Context, page int, size int) ([]*BaseFilmwork, error) {
+	scored, err := s.activityRepo.Popular(ctx, (page-1)*size, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank popular filmworks: %w", err)
+	}
+	return s.hydrate(ctx, scored)
+}
+
+func (s *filmworkServiceImpl) Releases(ctx context.Context, from *Date, to *Date, page int, size int) ([]*DatedFilmwork, error) {
+	filmworks, err := s.repo.Releases(ctx, from, to, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get releases: %w", err)
+	}
+	s.applyDatedRatings(ctx, filmworks)
+	return filmworks, nil
+}
+
+func (s *filmworkServiceImpl) Upcoming(ctx context.Context, page int, size int) ([]*DatedFilmwork, error) {
+	tomorrow := Date{Today().AddDate(0, 0, 1)}
+	return s.Releases(ctx, &tomorrow, nil, page, size)
+}
+
+func (s *filmworkServiceImpl) Anniversaries(ctx context.Context, date Date, page int, size int) ([]*DatedFilmwork, error) {
+	filmworks, err := s.repo.Anniversaries(ctx, date, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get anniversaries: %w", err)
+	}
+	s.applyDatedRatings(ctx, filmworks)
+	return filmworks, nil
+}
+
+func (s *filmworkServiceImpl) Discover(ctx context.Context, seed *int64, filter DiscoverFilter, page int, size int) (*Discovery, error) {
+	discovery := &Discovery{}
+	if seed != nil {
+		discovery.Seed = *seed
+	} else {
+		discovery.Seed = int64(rand.Int31())
+	}
+	filmworks, err := s.repo.Discover(ctx, discovery.Seed, filter, page, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover filmworks: %w", err)
+	}
+	s.applyRatings(ctx, filmworks)
+	discovery.Items = filmworks
+	return discovery, nil
+}
+
+func (s *filmworkServiceImpl) applyDatedRatings(ctx context.Context, filmworks []*DatedFilmwork) {
+	base := make([]*BaseFilmwork, 0, len(filmworks))
+	for _, f := range filmworks {
+		base = append(base, &f.BaseFilmwork)
+	}
+	s.applyRatings(ctx, base)
+}
+
+// hydrate looks the ranked filmworks up in the catalogue, keeping their order
+// and dropping the ones that no longer exist.
+func (s *filmworkServiceImpl) hydrate(ctx context.Context, scored []activity.Scored) ([]*BaseFilmwork, error) {
+	ids := make([]string, 0, len(scored))
+	for _, sc := range scored {
+		ids = append(ids, sc.ID)
+	}
+	found, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filmworks: %w", err)
+	}
+
+	filmworks := make([]*BaseFilmwork, 0, len(ids))
+	for _, id := range ids {
+		if f, ok := found[id]; ok {
+			filmworks = append(filmworks, &BaseFilmwork{ID: f.ID, Title: f.Title, Rating: f.Rating})
+		}
+	}
+	s.applyRatings(ctx, filmworks)
 	return filmworks, nil
 }
+
+func (s *filmworkServiceImpl) RecordView(ctx context.Context, id string) {
+	if err := s.activityRepo.RecordView(ctx, id); err != nil {
+		log.Printf("failed to record filmwork view: %v", err)
+	}
+}
+
+func (s *filmworkServiceImpl) SearchWithSuggestion(ctx context.Context, query string, limit int, autocorrect bool) (*FilmworkSearch, error) {
+	filmworks, err := s.Search(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := &FilmworkSearch{Items: filmworks}
+	if len(filmworks) > 0 || query == "" {
+		return result, nil
+	}
+	// Corrections are phrased for free text and would mangle field terms.
+	if parsed, err := ParseQuery(query); err != nil || !parsed.IsFreeText() {
+		return result, nil
+	}
+
+	// Suggestions only help, so a failing suggester leaves the result as is.
+	suggestion, err := s.repo.Suggest(ctx, query)
+	if err != nil {
+		log.Printf("failed to suggest filmwork search: %v", err)
+		return result, nil
+	}
+	if suggestion == "" {
+		return result, nil
+	}
+	result.Suggestion = &suggestion
+	if autocorrect {
+		if result.Items, err = s.Search(ctx, suggestion, limit); err != nil {
+			return nil, err
+		}
+		result.Corrected = true
+	}
+	return result, nil
+}
+
+// ratings looks up the audience ratings of ids in one batch. Ratings are an
+// enrichment, so a failing UGC store is logged and leaves them empty instead
+// of failing the request.
+func (s *filmworkServiceImpl) ratings(ctx context.Context, ids []string) map[string]rating.Rating {
+	if s.ratingRepo == nil || len(ids) == 0 {
+		return nil
+	}
+	ratings, err := s.ratingRepo.GetByFilmworkIDs(ctx, ids)
+	if err != nil {
+		log.Printf("failed to get user ratings: %v", err)
+		return nil
+	}
+	return ratings
+}
+
+func (s *filmworkServiceImpl) applyRatings(ctx context.Context, filmworks []*BaseFilmwork) {
+	ids := make([]string, 0, len(filmworks))
+	for _, f := range filmworks {
+		ids = append(ids, f.ID)
+	}
+	ratings := s.ratings(ctx, ids)
+	for _, f := range filmworks {
+		f.UserRating, f.VotesCount = ratings[f.ID].UserRating, ratings[f.ID].VotesCount
+	}
+}
+
+func (s *filmworkServiceImpl) GetByIDs(ctx context.Context, ids []string) (*FilmworkBatch, error) {
+	found, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filmworks: %w", err)
+	}
+	batch := &FilmworkBatch{
+		Items:   make([]*Filmwork, 0, len(ids)),
+		Missing: []string{},
+	}
+	for _, id := range ids {
+		if item, ok := found[id]; ok {
+			batch.Items = append(batch.Items, item)
+		} else {
+			batch.Missing = append(batch.Missing, id)
+		}
+	}
+	foundIDs := make([]string, 0, len(found))
+	for id := range found {
+		foundIDs = append(foundIDs, id)
+	}
+	ratings := s.ratings(ctx, foundIDs)
+	for _, f := range batch.Items {
+		f.UserRating, f.VotesCount = ratings[f.ID].UserRating, ratings[f.ID].VotesCount
+	}
+	return batch, nil
+}
+
+func (s *filmworkServiceImpl) GetByIDView(ctx context.Context, id string, fields []string, expand []string) (map[string]interface{}, error) {
+	var includes []string
+	if len(fields) > 0 {
+		includes = []string{"id"}
+		for _, field := range slices.Concat(fields, expand) {
+			if !slices.Contains(includes, field) && !slices.Contains(ratingFields, field) {
+				includes = append(includes, field)
+			}
+		}
+		slices.Sort(includes)
+	}
+
+	source, err := s.repo.GetSource(ctx, id, includes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filmwork: %w", err)
+	}
+
+	view := make(map[string]interface{}, len(source))
+	for _, field := range Fields {
+		if value, ok := source[field]; ok && (len(fields) == 0 || slices.Contains(fields, field) || slices.Contains(expand, field)) {
+			view[field] = value
+		}
+	}
+
+	if len(fields) == 0 || slices.ContainsFunc(ratingFields, func(f string) bool { return slices.Contains(fields, f) }) {
+		r := s.ratings(ctx, []string{id})[id]
+		if len(fields) == 0 || slices.Contains(fields, "user_rating") {
+			view["user_rating"] = r.UserRating
+		}
+		if len(fields) == 0 || slices.Contains(fields, "votes_count") {
+			view["votes_count"] = r.VotesCount
+		}
+	}
+
+	if err := s.expandPersons(ctx, source, expand, view); err != nil {
+		return nil, err
+	}
+	if slices.Contains(expand, "genres") {
+		if err := s.expandGenres(ctx, source, view); err != nil {
+			return nil, err
+		}
+	}
+	return view, nil
+}
+
+// expandPersons replaces the requested cast fields with full persons fetched
+// in a single batch. Persons missing from the persons index keep the id and
+// name stored in the filmwork.
+func (s *filmworkServiceImpl) expandPersons(ctx context.Context, source map[string]json.RawMessage, expand []string, view map[string]interface{}) error {
+	cast := make(map[string][]person.BasePerson)
+	var ids []string
+	for _, field := range []string{"actors", "writers", "directors"} {
+		if !slices.Contains(expand, field) {
+			continue
+		}
+		var refs []person.BasePerson
+		if raw, ok := source[field]; ok {
+			if err := json.Unmarshal(raw, &refs); err != nil {
+				return fmt.Errorf("failed to decode %s: %w", field, err)
+			}
+		}
+		cast[field] = refs
+		for _, ref := range refs {
+			if !slices.Contains(ids, ref.ID) {
+				ids = append(ids, ref.ID)
+			}
+		}
+	}
+	if len(cast) == 0 {
+		return nil
+	}
+
+	found := map[string]*person.Person{}
+	if len(ids) > 0 {
+		var err error
+		found, err = s.personRepo.GetByIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to expand persons: %w", err)
+		}
+	}
+
+	for field, refs := range cast {
+		persons := make([]*person.Person, 0, len(refs))
+		for _, ref := range refs {
+			if p, ok := found[ref.ID]; ok {
+				persons = append(persons, p)
+			} else {
+				persons = append(persons, &person.Person{ID: ref.ID, Name: ref.Name})
+			}
+		}
+		view[field] = persons
+	}
+	return nil
+}
+
+// expandGenres replaces the genre names with full genres. Names unknown to the
+// genres index are returned with the name only.
+func (s *filmworkServiceImpl) expandGenres(ctx context.Context, source map[string]json.RawMessage, view map[string]interface{}) error {
+	var names []string
+	if raw, ok := source["genres"]; ok {
+		if err := json.Unmarshal(raw, &names); err != nil {
+			return fmt.Errorf("failed to decode genres: %w", err)
+		}
+	}
+
+	found := map[string]*genre.Genre{}
+	if len(names) > 0 {
+		var err error
+		found, err = s.genreRepo.GetByNames(ctx, names)
+		if err != nil {
+			return fmt.Errorf("failed to expand genres: %w", err)
+		}
+	}
+
+	genres := make([]*genre.Genre, 0, len(names))
+	for _, name := range names {
+		if g, ok := found[name]; ok {
+			genres = append(genres, g)
+		} else {
+			genres = append(genres, &genre.Genre{Name: name})
+		}
+	}
+	view["genres"] = genres
+	return nil
+}
@@ -0,0 +1,74 @@
+package genre
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"async-api/internal/index"
+	"async-api/pkg/cache"
+)
+
+type cachedRepository struct {
+	repo  Repository
+	cache *cache.Cache
+}
+
+func NewCachedGenreRepository(repo Repository, c *cache.Cache) Repository {
+	return &cachedRepository{repo: repo, cache: c}
+}
+
+func (r *cachedRepository) GetByID(ctx context.Context, genreId string) (*Genre, error) {
+	key := "genres:id:" + genreId
+	return cache.FetchTagged(ctx, r.cache, key, genreTags, func(ctx context.Context) (*Genre, error) {
+		return r.repo.GetByID(ctx, genreId)
+	})
+}
+
+func (r *cachedRepository) GetAll(ctx context.Context) ([]*Genre, error) {
+	return cache.FetchTagged(ctx, r.cache, "genres:all", genreListTags, func(ctx context.Context) ([]*Genre, error) {
+		return r.repo.GetAll(ctx)
+	})
+}
+
+func (r *cachedRepository) Version(ctx context.Context, genreId string) (*index.Version, error) {
+	key := "genres:version:" + genreId
+	tags := func(*index.Version) []string {
+		return []string{cache.Tag(cache.EntityGenre, genreId)}
+	}
+	return cache.FetchTagged(ctx, r.cache, key, tags, func(ctx context.Context) (*index.Version, error) {
+		return r.repo.Version(ctx, genreId)
+	})
+}
+
+func genreTags(g *Genre) []string {
+	return []string{cache.Tag(cache.EntityGenre, g.ID)}
+}
+
+func genreListTags(genres []*Genre) []string {
+	tags := make([]string, 0, len(genres))
+	for _, g := range genres {
+		tags = append(tags, cache.Tag(cache.EntityGenre, g.ID))
+	}
+	return tags
+}
+
+func (r *cachedRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*Genre, error) {
+	key := func(id string) string { return "genres:id:" + id }
+	return cache.FetchMany(ctx, r.cache, ids, key, genreTags, r.repo.GetByIDs)
+}
+
+func (r *cachedRepository) GetByNames(ctx context.Context, names []string) (map[string]*Genre, error) {
+	sorted := slices.Sorted(slices.Values(names))
+	key := "genres:names:" + strings.Join(sorted, ",")
+	tags := func(genres map[string]*Genre) []string {
+		tags := make([]string, 0, len(genres))
+		for _, g := range genres {
+			tags = append(tags, cache.Tag(cache.EntityGenre, g.ID))
+		}
+		return tags
+	}
+	return cache.FetchTagged(ctx, r.cache, key, tags, func(ctx context.Context) (map[string]*Genre, error) {
+		return r.repo.GetByNames(ctx, names)
+	})
+}
@@ -2,9 +2,10 @@ package genre
 
 import (
 	"net/http"
-	"strings"
 
 	"async-api/internal/http"
+	"async-api/internal/httpcache"
+	"async-api/pkg/cache"
 	"github.com/gorilla/mux"
 )
 
@@ -17,6 +18,8 @@ func NewGenreHandler(service GenreService) *GenreHandler {
 }
 
 func (h *GenreHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/genres/batch", h.GetByIDs).Methods("POST")
+	router.HandleFunc("/genres", h.GetByIDs).Methods("GET").Queries("ids", "{ids}")
 	router.HandleFunc("/genres", h.GetAll).Methods("GET")
 	router.HandleFunc("/genres/{id}", h.GetByID).Methods("GET")
 }
@@ -24,13 +27,18 @@ func (h *GenreHandler) RegisterRoutes(router *mux.Router) {
 func (h *GenreHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
+	version, err := h.service.Version(r.Context(), id)
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	httpcache.SetSurrogateKeys(w, cache.Tag(cache.EntityGenre, id))
+	if httpcache.NotModified(w, r, httpcache.Validators{ETag: httpcache.VersionETag(version), LastModified: version.Modified}) {
+		return
+	}
 	g, err := h.service.GetByID(r.Context(), id)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "not found") {
-			statusCode = http.StatusNotFound
-		}
-		response.SendErrorResponse(w, err.Error(), statusCode)
+		response.SendServiceErrorResponse(w, err)
 		return
 	}
 	response.SendSuccessResponse(w, g, http.StatusOK)
@@ -39,8 +47,24 @@ func (h *GenreHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 func (h *GenreHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	genres, err := h.service.GetAll(r.Context())
 	if err != nil {
-		response.SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		response.SendServiceErrorResponse(w, err)
 		return
 	}
+	httpcache.SetSurrogateKeys(w, genreListTags(genres)...)
 	response.SendSuccessResponse(w, genres, http.StatusOK)
 }
+
+func (h *GenreHandler) GetByIDs(w http.ResponseWriter, r *http.Request) {
+	ids, err := response.ReadIDs(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	batch, err := h.service.GetByIDs(r.Context(), ids)
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	httpcache.SetSurrogateKeys(w, genreListTags(batch.Items)...)
+	response.SendSuccessResponse(w, batch, http.StatusOK)
+}
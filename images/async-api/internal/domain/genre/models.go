@@ -5,3 +5,10 @@ type Genre struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 }
+
+// GenreBatch is the result of a batch lookup: the found genres in request
+// order and the ids that do not exist.
+type GenreBatch struct {
+	Items   []*Genre `json:"items"`
+	Missing []string `json:"missing"`
+}
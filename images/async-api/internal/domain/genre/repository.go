@@ -10,28 +10,44 @@ import (
 	"io"
 
 	"github.com/elastic/go-elasticsearch/v9/esapi"
+
+	"async-api/internal/config"
+	"async-api/internal/index"
 )
 
 type Repository interface {
 	GetByID(ctx context.Context, genreId string) (*Genre, error)
+	// GetByIDs looks the genres up with a single multi-get. Ids that do not
+	// exist are absent from the result.
+	GetByIDs(ctx context.Context, ids []string) (map[string]*Genre, error)
 	GetAll(ctx context.Context) ([]*Genre, error)
+	// GetByNames looks genres up by their exact names, which is how filmwork
+	// documents reference them. Unknown names are absent from the result.
+	GetByNames(ctx context.Context, names []string) (map[string]*Genre, error)
+	// Version looks up the version of the genre document without its source.
+	Version(ctx context.Context, genreId string) (*index.Version, error)
 }
 
 type genreRepository struct {
-	es *elasticsearch.Client
+	es       *elasticsearch.Client
+	indices  config.ElasticIndicesConfig
+	timeouts config.ElasticTimeoutsConfig
 }
 
-func NewGenreRepository(es *elasticsearch.Client) Repository {
-	return &genreRepository{es: es}
+func NewGenreRepository(es *elasticsearch.Client, cfg config.ElasticConfig) Repository {
+	return &genreRepository{es: es, indices: cfg.Indices, timeouts: cfg.Timeouts}
 }
 
 func (r *genreRepository) GetByID(ctx context.Context, genreId string) (*Genre, error) {
 	req := esapi.GetRequest{
-		Index:      "genres",
+		Index:      r.indices.Genres,
 		DocumentID: genreId,
 	}
 
-	resp, err := req.Do(ctx, r.es)
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
 	if err != nil {
 		return nil, fmt.Errorf("Elasticsearch request error: %w", err)
 	}
@@ -55,6 +71,38 @@ func (r *genreRepository) GetByID(ctx context.Context, genreId string) (*Genre,
 	return &response.Source, nil
 }
 
+func (r *genreRepository) Version(ctx context.Context, genreId string) (*index.Version, error) {
+	req := esapi.GetRequest{
+		Index:          r.indices.Genres,
+		DocumentID:     genreId,
+		SourceIncludes: index.VersionFields,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		if resp.StatusCode == 404 {
+			return nil, fmt.Errorf("genre with ID '%s' not found", genreId)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Elasticsearch error [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response index.VersionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	return response.Version(), nil
+}
+
 func (r *genreRepository) GetAll(ctx context.Context) ([]*Genre, error) {
 	query := map[string]interface{}{
 		"query": map[string]interface{}{
@@ -69,11 +117,14 @@ func (r *genreRepository) GetAll(ctx context.Context) ([]*Genre, error) {
 	}
 
 	req := esapi.SearchRequest{
-		Index: []string{"genres"},
+		Index: []string{r.indices.Genres},
 		Body:  &buf,
 	}
 
-	resp, err := req.Do(ctx, r.es)
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
 	if err != nil {
 		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
 	}
@@ -111,3 +162,106 @@ func (r *genreRepository) GetAll(ctx context.Context) ([]*Genre, error) {
 
 	return genres, nil
 }
+
+func (r *genreRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*Genre, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]interface{}{"ids": ids}); err != nil {
+		return nil, fmt.Errorf("request coding error: %w", err)
+	}
+
+	req := esapi.MgetRequest{
+		Index: r.indices.Genres,
+		Body:  &buf,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Elasticsearch error [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		Docs []struct {
+			ID     string `json:"_id"`
+			Found  bool   `json:"found"`
+			Source Genre  `json:"_source"`
+		} `json:"docs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	genres := make(map[string]*Genre, len(response.Docs))
+	for _, doc := range response.Docs {
+		if !doc.Found {
+			continue
+		}
+		source := doc.Source
+		genres[doc.ID] = &source
+	}
+
+	return genres, nil
+}
+
+func (r *genreRepository) GetByNames(ctx context.Context, names []string) (map[string]*Genre, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"terms": map[string]interface{}{
+				"name.raw": names,
+			},
+		},
+		"size": len(names),
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, fmt.Errorf("request coding error: %w", err)
+	}
+
+	req := esapi.SearchRequest{
+		Index: []string{r.indices.Genres},
+		Body:  &buf,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error Elasticsearch [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		Hits struct {
+			Hits []struct {
+				Source Genre `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	genres := make(map[string]*Genre, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		source := hit.Source
+		genres[source.Name] = &source
+	}
+
+	return genres, nil
+}
@@ -3,11 +3,16 @@ package genre
 import (
 	"context"
 	"fmt"
+
+	"async-api/internal/index"
 )
 
 type GenreService interface {
 	GetByID(ctx context.Context, id string) (*Genre, error)
+	GetByIDs(ctx context.Context, ids []string) (*GenreBatch, error)
 	GetAll(ctx context.Context) ([]*Genre, error)
+	// Version returns the version of the genre GetByID returns.
+	Version(ctx context.Context, id string) (*index.Version, error)
 }
 
 type genreServiceImpl struct {
@@ -28,6 +33,14 @@ func (s *genreServiceImpl) GetByID(ctx context.Context, id string) (*Genre, erro
 	return g, nil
 }
 
+func (s *genreServiceImpl) Version(ctx context.Context, id string) (*index.Version, error) {
+	v, err := s.repo.Version(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get genre: %w", err)
+	}
+	return v, nil
+}
+
 func (s *genreServiceImpl) GetAll(ctx context.Context) ([]*Genre, error) {
 	genres, err := s.repo.GetAll(ctx)
 	if err != nil {
@@ -35,3 +48,22 @@ func (s *genreServiceImpl) GetAll(ctx context.Context) ([]*Genre, error) {
 	}
 	return genres, nil
 }
+
+func (s *genreServiceImpl) GetByIDs(ctx context.Context, ids []string) (*GenreBatch, error) {
+	found, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get genres: %w", err)
+	}
+	batch := &GenreBatch{
+		Items:   make([]*Genre, 0, len(ids)),
+		Missing: []string{},
+	}
+	for _, id := range ids {
+		if item, ok := found[id]; ok {
+			batch.Items = append(batch.Items, item)
+		} else {
+			batch.Missing = append(batch.Missing, id)
+		}
+	}
+	return batch, nil
+}
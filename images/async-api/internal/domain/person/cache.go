@@ -0,0 +1,147 @@
+package person
+
+import (
+	"context"
+	"fmt"
+
+	"async-api/pkg/cache"
+)
+
+type cachedRepository struct {
+	repo  Repository
+	cache *cache.Cache
+}
+
+func NewCachedPersonRepository(repo Repository, c *cache.Cache) Repository {
+	return &cachedRepository{repo: repo, cache: c}
+}
+
+func (r *cachedRepository) GetByID(ctx context.Context, personId string) (*Person, error) {
+	key := "persons:id:" + personId
+	return cache.FetchTagged(ctx, r.cache, key, personTags, func(ctx context.Context) (*Person, error) {
+		return r.repo.GetByID(ctx, personId)
+	})
+}
+
+func (r *cachedRepository) GetAll(ctx context.Context, page int, size int) ([]*Person, error) {
+	key := fmt.Sprintf("persons:all:%d:%d", page, size)
+	return cache.FetchTagged(ctx, r.cache, key, personListTags, func(ctx context.Context) ([]*Person, error) {
+		return r.repo.GetAll(ctx, page, size)
+	})
+}
+
+func (r *cachedRepository) Search(ctx context.Context, query string, limit int) ([]*Person, error) {
+	key := fmt.Sprintf("persons:search:%d:%s", limit, query)
+	return cache.FetchTagged(ctx, r.cache, key, personListTags, func(ctx context.Context) ([]*Person, error) {
+		return r.repo.Search(ctx, query, limit)
+	})
+}
+
+func (r *cachedRepository) Suggest(ctx context.Context, query string) (string, error) {
+	return cache.Fetch(ctx, r.cache, "persons:suggest:"+query, func(ctx context.Context) (string, error) {
+		return r.repo.Suggest(ctx, query)
+	})
+}
+
+func (r *cachedRepository) Filmworks(ctx context.Context, personId string) ([]*PersonBaseFilmwork, error) {
+	key := "persons:filmworks:" + personId
+	tags := func(filmworks []*PersonBaseFilmwork) []string {
+		return personFilmworksTags(personId, filmworks)
+	}
+	return cache.FetchTagged(ctx, r.cache, key, tags, func(ctx context.Context) ([]*PersonBaseFilmwork, error) {
+		return r.repo.Filmworks(ctx, personId)
+	})
+}
+
+func (r *cachedRepository) GetPersonFilmworkIDsAndRoles(ctx context.Context, personId string) (map[string][]string, error) {
+	return r.repo.GetPersonFilmworkIDsAndRoles(ctx, personId)
+}
+
+func (r *cachedRepository) Collaborators(ctx context.Context, personId string, role string, size int) ([]*Collaborator, error) {
+	key := fmt.Sprintf("persons:collaborators:%s:%s:%d", personId, role, size)
+	tags := func(collaborators []*Collaborator) []string {
+		return collaboratorsTags(personId, collaborators)
+	}
+	return cache.FetchTagged(ctx, r.cache, key, tags, func(ctx context.Context) ([]*Collaborator, error) {
+		return r.repo.Collaborators(ctx, personId, role, size)
+	})
+}
+
+// Neighbours memoises the neighbourhood of every person a path search visits,
+// so that later searches through the same part of the graph skip the query.
+func (r *cachedRepository) Neighbours(ctx context.Context, ids []string) (map[string]*Neighbourhood, error) {
+	key := func(id string) string { return "persons:neighbours:" + id }
+	return cache.FetchMany(ctx, r.cache, ids, key, neighbourhoodTags, r.repo.Neighbours)
+}
+
+func (r *cachedRepository) Career(ctx context.Context, personId string) (*Career, error) {
+	key := "persons:career:" + personId
+	tags := func(career *Career) []string {
+		return append(
+			[]string{cache.Tag(cache.EntityPerson, personId)},
+			cache.Tags(cache.EntityFilmwork, career.FilmworkIDs...)...,
+		)
+	}
+	return cache.FetchTagged(ctx, r.cache, key, tags, func(ctx context.Context) (*Career, error) {
+		return r.repo.Career(ctx, personId)
+	})
+}
+
+func personTags(p *Person) []string {
+	return append(
+		[]string{cache.Tag(cache.EntityPerson, p.ID)},
+		cache.Tags(cache.EntityFilmwork, p.FilmworkIDs...)...,
+	)
+}
+
+func personListTags(persons []*Person) []string {
+	tags := make([]string, 0, len(persons))
+	for _, p := range persons {
+		tags = append(tags, cache.Tag(cache.EntityPerson, p.ID))
+	}
+	return tags
+}
+
+func (r *cachedRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*Person, error) {
+	key := func(id string) string { return "persons:id:" + id }
+	return cache.FetchMany(ctx, r.cache, ids, key, personTags, r.repo.GetByIDs)
+}
+
+func personFilmworksTags(personId string, filmworks []*PersonBaseFilmwork) []string {
+	tags := []string{cache.Tag(cache.EntityPerson, personId)}
+	for _, f := range filmworks {
+		tags = append(tags, cache.Tag(cache.EntityFilmwork, f.ID))
+	}
+	return tags
+}
+
+func collaboratorsTags(personId string, collaborators []*Collaborator) []string {
+	tags := []string{cache.Tag(cache.EntityPerson, personId)}
+	for _, c := range collaborators {
+		tags = append(tags, cache.Tag(cache.EntityPerson, c.ID))
+		for _, f := range c.SampleFilmworks {
+			tags = append(tags, cache.Tag(cache.EntityFilmwork, f.ID))
+		}
+	}
+	return tags
+}
+
+func neighbourhoodTags(n *Neighbourhood) []string {
+	tags := []string{cache.Tag(cache.EntityPerson, n.ID)}
+	for _, e := range n.Edges {
+		tags = append(tags, cache.Tag(cache.EntityFilmwork, e.Filmwork.ID), cache.Tag(cache.EntityPerson, e.Person.ID))
+	}
+	return tags
+}
+
+func pathTags(path *PersonPath) []string {
+	tags := make([]string, 0, len(path.Path))
+	for _, node := range path.Path {
+		entity := cache.EntityPerson
+		if node.Type == "filmwork" {
+			entity = cache.EntityFilmwork
+		}
+		tags = append(tags, cache.Tag(entity, node.ID))
+	}
+	return tags
+}
@@ -1,11 +1,13 @@
 package person
 
 import (
+	"errors"
 	"net/http"
+	"slices"
 	"strconv"
-	"strings"
 
 	"async-api/internal/http"
+	"async-api/internal/httpcache"
 	"github.com/gorilla/mux"
 )
 
@@ -19,33 +21,87 @@ func NewPersonHandler(service PersonService) *PersonHandler {
 
 func (h *PersonHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/persons/search", h.Search).Methods("GET")
+	router.HandleFunc("/persons/batch", h.GetByIDs).Methods("POST")
+	router.HandleFunc("/persons", h.GetByIDs).Methods("GET").Queries("ids", "{ids}")
 	router.HandleFunc("/persons", h.GetAll).Methods("GET")
 	router.HandleFunc("/persons/{id}", h.GetByID).Methods("GET")
 	router.HandleFunc("/persons/{id}/filmworks", h.PersonFilmworks).Methods("GET")
+	router.HandleFunc("/persons/{id}/collaborators", h.Collaborators).Methods("GET")
+	router.HandleFunc("/persons/{id}/path/{to}", h.Path).Methods("GET")
 }
 
 func (h *PersonHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
+	fields, err := response.ReadList(r, "fields", Fields)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	expand, err := response.ReadList(r, "expand", Expandable)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	g, err := h.service.GetByID(r.Context(), id)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "not found") {
-			statusCode = http.StatusNotFound
-		}
-		response.SendErrorResponse(w, err.Error(), statusCode)
+		response.SendServiceErrorResponse(w, err)
 		return
 	}
-	response.SendSuccessResponse(w, g, http.StatusOK)
+	if len(fields) == 0 && len(expand) == 0 {
+		httpcache.SetSurrogateKeys(w, personTags(g)...)
+		response.SendSuccessResponse(w, g, http.StatusOK)
+		return
+	}
+
+	view, err := response.Project(g, fields)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if slices.Contains(expand, "filmworks") {
+		filmworks, err := h.service.GetPersonFilmworks(r.Context(), id)
+		if err != nil {
+			response.SendServiceErrorResponse(w, err)
+			return
+		}
+		view["filmworks"] = filmworks
+	}
+	if slices.Contains(expand, "career") {
+		career, err := h.service.GetCareer(r.Context(), id)
+		if err != nil {
+			response.SendServiceErrorResponse(w, err)
+			return
+		}
+		view["career"] = career
+	}
+	httpcache.SetSurrogateKeys(w, personTags(g)...)
+	response.SendSuccessResponse(w, view, http.StatusOK)
 }
 
 func (h *PersonHandler) Search(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
+	suggest, autocorrect, err := response.ReadSuggest(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if suggest {
+		result, err := h.service.SearchWithSuggestion(r.Context(), query, 1000, autocorrect)
+		if err != nil {
+			response.SendServiceErrorResponse(w, err)
+			return
+		}
+		httpcache.SetSurrogateKeys(w, personListTags(result.Items)...)
+		response.SendSuccessResponse(w, result, http.StatusOK)
+		return
+	}
 	persons, err := h.service.Search(r.Context(), query, 1000)
 	if err != nil {
-		response.SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		response.SendServiceErrorResponse(w, err)
 		return
 	}
+	httpcache.SetSurrogateKeys(w, personListTags(persons)...)
 	response.SendSuccessResponse(w, persons, http.StatusOK)
 }
 
@@ -76,9 +132,10 @@ func (h *PersonHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	}
 	persons, err := h.service.GetAll(r.Context(), pageNumber, pageSize)
 	if err != nil {
-		response.SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		response.SendServiceErrorResponse(w, err)
 		return
 	}
+	httpcache.SetSurrogateKeys(w, personListTags(persons)...)
 	response.SendSuccessResponse(w, persons, http.StatusOK)
 }
 
@@ -87,8 +144,68 @@ func (h *PersonHandler) PersonFilmworks(w http.ResponseWriter, r *http.Request)
 	id := vars["id"]
 	filmworks, err := h.service.GetPersonFilmworks(r.Context(), id)
 	if err != nil {
-		response.SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		response.SendServiceErrorResponse(w, err)
 		return
 	}
+	httpcache.SetSurrogateKeys(w, personFilmworksTags(id, filmworks)...)
 	response.SendSuccessResponse(w, filmworks, http.StatusOK)
 }
+
+func (h *PersonHandler) Collaborators(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	role := r.URL.Query().Get("role")
+	if role != "" && !slices.Contains(Roles, role) {
+		response.SendErrorResponse(w, "Неверный формат role", http.StatusBadRequest)
+		return
+	}
+	pageSize := 10
+	if pageSizeStr := r.URL.Query().Get("page_size"); pageSizeStr != "" {
+		if s, err := strconv.Atoi(pageSizeStr); err == nil && s > 0 {
+			pageSize = min(s, 100)
+		} else {
+			response.SendErrorResponse(w, "Неверный формат page_size", http.StatusBadRequest)
+			return
+		}
+	}
+	collaborators, err := h.service.GetCollaborators(r.Context(), id, role, pageSize)
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	httpcache.SetSurrogateKeys(w, collaboratorsTags(id, collaborators)...)
+	response.SendSuccessResponse(w, collaborators, http.StatusOK)
+}
+
+func (h *PersonHandler) Path(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	path, err := h.service.FindPath(r.Context(), vars["id"], vars["to"])
+	if err != nil {
+		if errors.Is(err, ErrPathNotFound) {
+			response.SendErrorResponse(w, "Путь не найден", http.StatusNotFound)
+			return
+		}
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	httpcache.SetSurrogateKeys(w, pathTags(path)...)
+	response.SendSuccessResponse(w, path, http.StatusOK)
+}
+
+func (h *PersonHandler) GetByIDs(w http.ResponseWriter, r *http.Request) {
+	ids, err := response.ReadIDs(r)
+	if err != nil {
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	batch, err := h.service.GetByIDs(r.Context(), ids)
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	var keys []string
+	for _, p := range batch.Items {
+		keys = append(keys, personTags(p)...)
+	}
+	httpcache.SetSurrogateKeys(w, keys...)
+	response.SendSuccessResponse(w, batch, http.StatusOK)
+}
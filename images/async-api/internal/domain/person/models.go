@@ -1,5 +1,14 @@
 package person
 
+// Fields are the Person fields that can be requested with fields=.
+var Fields = []string{"id", "name", "roles", "filmwork_ids"}
+
+// Expandable are the relationships expand= can resolve on a Person.
+var Expandable = []string{"filmworks", "career"}
+
+// Roles are the credits a person can have on a filmwork.
+var Roles = []string{"actor", "director", "writer"}
+
 type EsBasePerson struct {
 	ID   string `json:"id"`
 	Name string `json:"full_name"`
@@ -22,3 +31,87 @@ type PersonBaseFilmwork struct {
 	Title  string  `json:"title"`
 	Rating float32 `json:"rating"`
 }
+
+// PersonSearch is a search result with a spelling suggestion for queries that
+// found nothing. Corrected is set when Items are the results of the
+// suggestion instead of the query.
+type PersonSearch struct {
+	Items      []*Person `json:"items"`
+	Suggestion *string   `json:"suggestion"`
+	Corrected  bool      `json:"corrected"`
+}
+
+// PersonBatch is the result of a batch lookup: the found persons in request
+// order and the ids that do not exist.
+type PersonBatch struct {
+	Items   []*Person `json:"items"`
+	Missing []string  `json:"missing"`
+}
+
+// Collaborator is a person who shares filmworks with another one, with the
+// roles they had on those filmworks and a few of them, best rated first.
+type Collaborator struct {
+	ID              string                `json:"id"`
+	Name            string                `json:"name"`
+	Roles           []string              `json:"roles"`
+	SharedFilmworks int                   `json:"shared_filmworks"`
+	SampleFilmworks []*PersonBaseFilmwork `json:"sample_filmworks"`
+}
+
+// Neighbourhood is a person with the persons they share a filmwork with, one
+// edge per shared filmwork.
+type Neighbourhood struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Edges []Edge `json:"edges"`
+}
+
+type Edge struct {
+	Filmwork PersonBaseFilmwork `json:"filmwork"`
+	Person   BasePerson         `json:"person"`
+}
+
+// PathNode is a hop of a PersonPath: a person, or a filmwork shared by the
+// persons before and after it.
+type PathNode struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Title string `json:"title,omitempty"`
+}
+
+// PersonPath is the shortest chain of shared filmworks linking two persons.
+// Degrees is the number of filmworks in Path.
+type PersonPath struct {
+	Degrees int        `json:"degrees"`
+	Path    []PathNode `json:"path"`
+}
+
+// Career summarises the filmworks of a person. Years and ratings are nil when
+// none of the filmworks has one.
+type Career struct {
+	FirstYear     *int                `json:"first_year"`
+	LastYear      *int                `json:"last_year"`
+	Filmworks     int                 `json:"filmworks"`
+	Roles         map[string]int      `json:"roles"`
+	AverageRating *float64            `json:"average_rating"`
+	BestRating    *float64            `json:"best_rating"`
+	BestFilmwork  *PersonBaseFilmwork `json:"best_filmwork"`
+	Timeline      []CareerYear        `json:"timeline"`
+	TopGenres     []GenreCount        `json:"top_genres"`
+	// FilmworkIDs are the filmworks summarised, for cache invalidation.
+	FilmworkIDs []string `json:"-"`
+}
+
+// CareerYear counts the credits of a person in one release year, in total
+// and per role.
+type CareerYear struct {
+	Year    int            `json:"year"`
+	Credits int            `json:"credits"`
+	Roles   map[string]int `json:"roles"`
+}
+
+type GenreCount struct {
+	Genre string `json:"genre"`
+	Count int    `json:"count"`
+}
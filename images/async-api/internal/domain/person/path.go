@@ -0,0 +1,140 @@
+package person
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrPathNotFound is returned when no chain of at most the configured number
+// of shared filmworks links two persons.
+var ErrPathNotFound = errors.New("path not found")
+
+// pathLink is how a path search reached a person: from person, through
+// filmwork, depth filmworks away from where that side started.
+type pathLink struct {
+	person   string
+	filmwork PersonBaseFilmwork
+	depth    int
+}
+
+// pathSide is one direction of the bidirectional search.
+type pathSide struct {
+	parents  map[string]*pathLink
+	frontier []string
+	depth    int
+}
+
+func newPathSide(start string) *pathSide {
+	return &pathSide{
+		parents:  map[string]*pathLink{start: nil},
+		frontier: []string{start},
+	}
+}
+
+// FindPath runs a breadth-first search from both persons at once, always
+// expanding the side with the smaller frontier by one filmwork, until the
+// sides meet, the depth limit is reached or the time budget runs out.
+func (s *personServiceImpl) FindPath(ctx context.Context, from string, to string) (*PersonPath, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.path.Timeout)
+	defer cancel()
+
+	names := make(map[string]string)
+	if from == to {
+		hoods, err := s.neighbours(ctx, []string{from})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find path: %w", err)
+		}
+		hood, ok := hoods[from]
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+		return &PersonPath{Path: []PathNode{{Type: "person", ID: from, Name: hood.Name}}}, nil
+	}
+
+	forward, backward := newPathSide(from), newPathSide(to)
+	for forward.depth+backward.depth < s.path.MaxDepth && len(forward.frontier) > 0 && len(backward.frontier) > 0 {
+		side, other := forward, backward
+		if len(backward.frontier) < len(forward.frontier) {
+			side, other = backward, forward
+		}
+
+		hoods, err := s.neighbours(ctx, side.frontier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find path: %w", err)
+		}
+		side.depth++
+
+		var next []string
+		meeting := ""
+		for _, id := range side.frontier {
+			hood, ok := hoods[id]
+			if !ok {
+				continue
+			}
+			names[id] = hood.Name
+			for _, edge := range hood.Edges {
+				names[edge.Person.ID] = edge.Person.Name
+				if _, seen := side.parents[edge.Person.ID]; seen {
+					continue
+				}
+				side.parents[edge.Person.ID] = &pathLink{person: id, filmwork: edge.Filmwork, depth: side.depth}
+				next = append(next, edge.Person.ID)
+				// The other side may have reached the person at different
+				// depths; the shallowest one gives the shortest path.
+				if link, ok := other.parents[edge.Person.ID]; ok && (meeting == "" || linkDepth(link) < linkDepth(other.parents[meeting])) {
+					meeting = edge.Person.ID
+				}
+			}
+		}
+		if meeting != "" {
+			return joinPath(forward, backward, meeting, names), nil
+		}
+		side.frontier = next
+	}
+	return nil, fmt.Errorf("%w within %d filmworks", ErrPathNotFound, s.path.MaxDepth)
+}
+
+// neighbours looks ids up in batches of the configured size.
+func (s *personServiceImpl) neighbours(ctx context.Context, ids []string) (map[string]*Neighbourhood, error) {
+	hoods := make(map[string]*Neighbourhood, len(ids))
+	for start := 0; start < len(ids); start += s.path.BatchSize {
+		batch, err := s.repo.Neighbours(ctx, ids[start:min(start+s.path.BatchSize, len(ids))])
+		if err != nil {
+			return nil, err
+		}
+		for id, hood := range batch {
+			hoods[id] = hood
+		}
+	}
+	return hoods, nil
+}
+
+func linkDepth(link *pathLink) int {
+	if link == nil {
+		return 0
+	}
+	return link.depth
+}
+
+// joinPath walks from meeting back to the start of each side and joins the
+// two halves into one chain from forward's start to backward's.
+func joinPath(forward *pathSide, backward *pathSide, meeting string, names map[string]string) *PersonPath {
+	person := func(id string) PathNode {
+		return PathNode{Type: "person", ID: id, Name: names[id]}
+	}
+	filmwork := func(f PersonBaseFilmwork) PathNode {
+		return PathNode{Type: "filmwork", ID: f.ID, Title: f.Title}
+	}
+
+	path := []PathNode{person(meeting)}
+	for id := meeting; forward.parents[id] != nil; id = forward.parents[id].person {
+		link := forward.parents[id]
+		path = append([]PathNode{person(link.person), filmwork(link.filmwork)}, path...)
+	}
+	for id := meeting; backward.parents[id] != nil; id = backward.parents[id].person {
+		link := backward.parents[id]
+		path = append(path, filmwork(link.filmwork), person(link.person))
+	}
+	return &PersonPath{Degrees: len(path) / 2, Path: path}
+}
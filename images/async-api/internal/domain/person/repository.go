@@ -6,34 +6,78 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v9"
 	"github.com/elastic/go-elasticsearch/v9/esapi"
+
+	"async-api/internal/config"
+	"async-api/internal/index"
+	"async-api/pkg/translit"
 )
 
 type Repository interface {
 	GetByID(ctx context.Context, personId string) (*Person, error)
+	// GetByIDs looks the persons up with a single multi-get and resolves
+	// their filmworks and roles with one scan. Ids that do not exist are
+	// absent from the result.
+	GetByIDs(ctx context.Context, ids []string) (map[string]*Person, error)
 	GetAll(ctx context.Context, page int, size int) ([]*Person, error)
 	Search(ctx context.Context, query string, limit int) ([]*Person, error)
+	// Suggest returns a spelling correction of query taken from person
+	// names, or an empty string when there is none.
+	Suggest(ctx context.Context, query string) (string, error)
 	Filmworks(ctx context.Context, personId string) ([]*PersonBaseFilmwork, error)
 	GetPersonFilmworkIDsAndRoles(ctx context.Context, personId string) (map[string][]string, error)
+	// Collaborators returns up to size persons who most often share
+	// filmworks with personId, limited to those credited in role unless role
+	// is empty.
+	Collaborators(ctx context.Context, personId string, role string, size int) ([]*Collaborator, error)
+	// Neighbours returns the neighbourhoods of ids in the cast and crew
+	// graph, scanning every filmwork that credits them. Ids credited on no
+	// filmwork are absent.
+	Neighbours(ctx context.Context, ids []string) (map[string]*Neighbourhood, error)
+	// Career summarises the filmworks of personId with a single aggregation
+	// and lists the filmworks summarised.
+	Career(ctx context.Context, personId string) (*Career, error)
 }
 
 type personRepository struct {
-	es *elasticsearch.Client
+	es       *elasticsearch.Client
+	scanner  *index.Scanner
+	indices  config.ElasticIndicesConfig
+	timeouts config.ElasticTimeoutsConfig
 }
 
-func NewPersonRepository(es *elasticsearch.Client) Repository {
-	return &personRepository{es: es}
+// Filmworks of many persons at once are scanned rather than searched, since a
+// search returns at most index.max_result_window hits.
+const (
+	scanPageSize  = 1000
+	scanKeepAlive = time.Minute
+)
+
+func NewPersonRepository(es *elasticsearch.Client, cfg config.ElasticConfig) Repository {
+	return &personRepository{
+		es:       es,
+		scanner:  index.NewScanner(es, scanPageSize, scanKeepAlive, cfg.Timeouts.Search),
+		indices:  cfg.Indices,
+		timeouts: cfg.Timeouts,
+	}
 }
 
 func (r *personRepository) GetByID(ctx context.Context, personId string) (*Person, error) {
 	req := esapi.GetRequest{
-		Index:      "persons",
+		Index:      r.indices.Persons,
 		DocumentID: personId,
 	}
 
-	resp, err := req.Do(ctx, r.es)
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
 	if err != nil {
 		return nil, fmt.Errorf("Elasticsearch request error: %w", err)
 	}
@@ -83,11 +127,14 @@ func (r *personRepository) GetAll(ctx context.Context, page int, size int) ([]*P
 	}
 
 	req := esapi.SearchRequest{
-		Index: []string{"persons"},
+		Index: []string{r.indices.Persons},
 		Body:  &buf,
 	}
 
-	resp, err := req.Do(ctx, r.es)
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
 	if err != nil {
 		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
 	}
@@ -130,21 +177,92 @@ func (r *personRepository) GetAll(ctx context.Context, page int, size int) ([]*P
 	return persons, nil
 }
 
+// transliteratedBoost is the weight of a match on a transliterated variant of
+// the query relative to a match on the query as typed.
+const transliteratedBoost = 0.5
+
+// SearchQuery returns the query clause /persons/search runs for q. Besides q
+// itself it matches the transliterations of q, so that "Tarkovsky" finds
+// "Тарковский", ranking them below matches in the script of q. Every term
+// tolerates typos.
+func SearchQuery(q string) map[string]interface{} {
+	should := []map[string]interface{}{personMatch(q, 1)}
+	if normalized := translit.Normalize(q); normalized != q {
+		should = append(should, personMatch(normalized, 1))
+	}
+	for _, variant := range translit.Variants(q) {
+		should = append(should, personMatch(variant, transliteratedBoost))
+	}
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should":               should,
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+func personMatch(q string, boost float64) map[string]interface{} {
+	return map[string]interface{}{
+		"multi_match": map[string]interface{}{
+			"query":         q,
+			"fields":        []string{"full_name", "full_name.raw"},
+			"operator":      "and",
+			"type":          "best_fields",
+			"fuzziness":     "AUTO",
+			"prefix_length": 1,
+			"boost":         boost,
+		},
+	}
+}
+
+func (r *personRepository) Suggest(ctx context.Context, queryStr string) (string, error) {
+	query := map[string]interface{}{
+		"size":    0,
+		"suggest": index.PhraseSuggest(queryStr, []string{"full_name"}),
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return "", fmt.Errorf("request coding error: %w", err)
+	}
+
+	req := esapi.SearchRequest{
+		Index: []string{r.indices.Persons},
+		Body:  &buf,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return "", fmt.Errorf("Elasticsearch search error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("error Elasticsearch [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		Suggest index.Suggestions `json:"suggest"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("response parsing error: %w", err)
+	}
+
+	return response.Suggest.Best(), nil
+}
+
 func (r *personRepository) Search(ctx context.Context, queryStr string, limit int) ([]*Person, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 
 	query := map[string]interface{}{
-		"query": map[string]interface{}{
-			"multi_match": map[string]interface{}{
-				"query":    queryStr,
-				"fields":   []string{"full_name", "full_name.raw"},
-				"operator": "and",
-				"type":     "best_fields",
-			},
-		},
-		"size": limit,
+		"query": SearchQuery(queryStr),
+		"size":  limit,
 		"sort": []map[string]interface{}{
 			{"_score": map[string]interface{}{"order": "desc"}},
 		},
@@ -156,11 +274,14 @@ func (r *personRepository) Search(ctx context.Context, queryStr string, limit in
 	}
 
 	req := esapi.SearchRequest{
-		Index: []string{"persons"},
+		Index: []string{r.indices.Persons},
 		Body:  &buf,
 	}
 
-	resp, err := req.Do(ctx, r.es)
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
 	if err != nil {
 		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
 	}
@@ -203,45 +324,45 @@ func (r *personRepository) Search(ctx context.Context, queryStr string, limit in
 	return persons, nil
 }
 
-func (r *personRepository) Filmworks(ctx context.Context, personId string) ([]*PersonBaseFilmwork, error) {
-	query := map[string]interface{}{
-		"query": map[string]interface{}{
-			"bool": map[string]interface{}{
-				"should": []map[string]interface{}{
-					{
-						"nested": map[string]interface{}{
-							"path": "actors",
-							"query": map[string]interface{}{
-								"match": map[string]interface{}{
-									"actors.id": personId,
-								},
-							},
-						},
-					},
-					{
-						"nested": map[string]interface{}{
-							"path": "directors",
-							"query": map[string]interface{}{
-								"match": map[string]interface{}{
-									"directors.id": personId,
-								},
-							},
-						},
-					},
-					{
-						"nested": map[string]interface{}{
-							"path": "writers",
-							"query": map[string]interface{}{
-								"match": map[string]interface{}{
-									"writers.id": personId,
-								},
-							},
-						},
-					},
+// rolePaths are the movies index fields holding the persons of each role.
+var rolePaths = map[string]string{
+	"actor":    "actors",
+	"director": "directors",
+	"writer":   "writers",
+}
+
+// filmworksQuery matches the filmworks personId is credited on in any role.
+func filmworksQuery(personId string) map[string]interface{} {
+	should := make([]map[string]interface{}, 0, len(Roles))
+	for _, role := range Roles {
+		should = append(should, roleQuery(personId, role))
+	}
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should": should,
+		},
+	}
+}
+
+// roleQuery matches the filmworks personId is credited on in role.
+func roleQuery(personId string, role string) map[string]interface{} {
+	path := rolePaths[role]
+	return map[string]interface{}{
+		"nested": map[string]interface{}{
+			"path": path,
+			"query": map[string]interface{}{
+				"match": map[string]interface{}{
+					path + ".id": personId,
 				},
 			},
 		},
-		"size": 1000,
+	}
+}
+
+func (r *personRepository) Filmworks(ctx context.Context, personId string) ([]*PersonBaseFilmwork, error) {
+	query := map[string]interface{}{
+		"query": filmworksQuery(personId),
+		"size":  1000,
 		"sort": map[string]interface{}{
 			"rating": map[string]interface{}{
 				"order": "desc",
@@ -256,11 +377,14 @@ func (r *personRepository) Filmworks(ctx context.Context, personId string) ([]*P
 	}
 
 	req := esapi.SearchRequest{
-		Index: []string{"movies"},
+		Index: []string{r.indices.Movies},
 		Body:  &buf,
 	}
 
-	resp, err := req.Do(ctx, r.es)
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
 	if err != nil {
 		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
 	}
@@ -306,42 +430,7 @@ func (r *personRepository) GetPersonFilmworkIDsAndRoles(ctx context.Context, per
 	}
 
 	query := map[string]interface{}{
-		"query": map[string]interface{}{
-			"bool": map[string]interface{}{
-				"should": []map[string]interface{}{
-					{
-						"nested": map[string]interface{}{
-							"path": "actors",
-							"query": map[string]interface{}{
-								"match": map[string]interface{}{
-									"actors.id": personId,
-								},
-							},
-						},
-					},
-					{
-						"nested": map[string]interface{}{
-							"path": "directors",
-							"query": map[string]interface{}{
-								"match": map[string]interface{}{
-									"directors.id": personId,
-								},
-							},
-						},
-					},
-					{
-						"nested": map[string]interface{}{
-							"path": "writers",
-							"query": map[string]interface{}{
-								"match": map[string]interface{}{
-									"writers.id": personId,
-								},
-							},
-						},
-					},
-				},
-			},
-		},
+		"query":   filmworksQuery(personId),
 		"size":    1000,
 		"_source": []string{"id", "actors", "directors", "writers"},
 	}
@@ -352,11 +441,14 @@ func (r *personRepository) GetPersonFilmworkIDsAndRoles(ctx context.Context, per
 	}
 
 	req := esapi.SearchRequest{
-		Index: []string{"movies"},
+		Index: []string{r.indices.Movies},
 		Body:  &buf,
 	}
 
-	resp, err := req.Do(ctx, r.es)
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
 	if err != nil {
 		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
 	}
@@ -431,3 +523,605 @@ func (r *personRepository) GetPersonFilmworkIDsAndRoles(ctx context.Context, per
 		"filmwork_ids": filmworkIDs,
 	}, nil
 }
+
+func (r *personRepository) GetByIDs(ctx context.Context, ids []string) (map[string]*Person, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]interface{}{"ids": ids}); err != nil {
+		return nil, fmt.Errorf("request coding error: %w", err)
+	}
+
+	req := esapi.MgetRequest{
+		Index: r.indices.Persons,
+		Body:  &buf,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Get)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Elasticsearch error [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		Docs []struct {
+			ID     string       `json:"_id"`
+			Found  bool         `json:"found"`
+			Source EsBasePerson `json:"_source"`
+		} `json:"docs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	persons := make(map[string]*Person, len(response.Docs))
+	for _, doc := range response.Docs {
+		if !doc.Found {
+			continue
+		}
+		persons[doc.ID] = &Person{
+			ID:          doc.Source.ID,
+			Name:        doc.Source.Name,
+			Roles:       []string{},
+			FilmworkIDs: []string{},
+		}
+	}
+	if len(persons) == 0 {
+		return persons, nil
+	}
+
+	if err := r.fillFilmworksAndRoles(ctx, persons); err != nil {
+		return nil, fmt.Errorf("failed to get filmworks: %w", err)
+	}
+
+	return persons, nil
+}
+
+// fillFilmworksAndRoles sets FilmworkIDs and Roles of all persons, keyed by
+// id, from a single search over the movies index.
+func (r *personRepository) fillFilmworksAndRoles(ctx context.Context, persons map[string]*Person) error {
+	ids := make([]string, 0, len(persons))
+	for id := range persons {
+		ids = append(ids, id)
+	}
+
+	roles := []struct {
+		name string
+		path string
+	}{
+		{"actor", "actors"},
+		{"director", "directors"},
+		{"writer", "writers"},
+	}
+
+	should := make([]map[string]interface{}, 0, len(roles))
+	for _, role := range roles {
+		should = append(should, map[string]interface{}{
+			"nested": map[string]interface{}{
+				"path": role.path,
+				"query": map[string]interface{}{
+					"terms": map[string]interface{}{
+						role.path + ".id": ids,
+					},
+				},
+			},
+		})
+	}
+
+	query := map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should": should,
+		},
+	}
+
+	type ref struct {
+		ID string `json:"id"`
+	}
+	seenRoles := make(map[string]map[string]struct{}, len(persons))
+	err := r.scanner.Scan(ctx, r.indices.Movies, query, []string{"id", "actors.id", "directors.id", "writers.id"}, func(page []json.RawMessage) error {
+		for _, raw := range page {
+			var source struct {
+				ID        string `json:"id"`
+				Actors    []ref  `json:"actors"`
+				Directors []ref  `json:"directors"`
+				Writers   []ref  `json:"writers"`
+			}
+			if err := json.Unmarshal(raw, &source); err != nil {
+				return fmt.Errorf("response parsing error: %w", err)
+			}
+			linked := make(map[string]struct{})
+			for i, refs := range [][]ref{source.Actors, source.Directors, source.Writers} {
+				for _, ref := range refs {
+					p, ok := persons[ref.ID]
+					if !ok {
+						continue
+					}
+					if _, ok := linked[ref.ID]; !ok {
+						linked[ref.ID] = struct{}{}
+						p.FilmworkIDs = append(p.FilmworkIDs, source.ID)
+					}
+					if seenRoles[ref.ID] == nil {
+						seenRoles[ref.ID] = make(map[string]struct{})
+					}
+					seenRoles[ref.ID][roles[i].name] = struct{}{}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for id, p := range persons {
+		for _, role := range roles {
+			if _, ok := seenRoles[id][role.name]; ok {
+				p.Roles = append(p.Roles, role.name)
+			}
+		}
+	}
+
+	return nil
+}
+
+const (
+	// maxSharedFilmworks bounds the shared filmworks counted per collaborator
+	// and role.
+	maxSharedFilmworks = 1000
+	// sampleFilmworks is the number of shared filmworks shown per
+	// collaborator.
+	sampleFilmworks = 3
+)
+
+func (r *personRepository) Collaborators(ctx context.Context, personId string, role string, size int) ([]*Collaborator, error) {
+	roles := Roles
+	if role != "" {
+		roles = []string{role}
+	}
+
+	aggs := make(map[string]interface{}, len(roles))
+	for _, role := range roles {
+		path := rolePaths[role]
+		aggs[role] = map[string]interface{}{
+			"nested": map[string]interface{}{"path": path},
+			"aggs": map[string]interface{}{
+				"persons": map[string]interface{}{
+					"terms": map[string]interface{}{
+						"field":   path + ".id",
+						"size":    size,
+						"exclude": []string{personId},
+					},
+					"aggs": map[string]interface{}{
+						"person": map[string]interface{}{
+							"top_hits": map[string]interface{}{"size": 1},
+						},
+						"filmworks": map[string]interface{}{
+							"reverse_nested": map[string]interface{}{},
+							"aggs": map[string]interface{}{
+								"ids": map[string]interface{}{
+									"terms": map[string]interface{}{"field": "id", "size": maxSharedFilmworks},
+								},
+								"sample": map[string]interface{}{
+									"top_hits": map[string]interface{}{
+										"size":    sampleFilmworks,
+										"sort":    []map[string]interface{}{{"rating": map[string]interface{}{"order": "desc"}}},
+										"_source": []string{"id", "title", "rating"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	query := map[string]interface{}{
+		"query": filmworksQuery(personId),
+		"size":  0,
+		"aggs":  aggs,
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, fmt.Errorf("request coding error: %w", err)
+	}
+
+	req := esapi.SearchRequest{
+		Index: []string{r.indices.Movies},
+		Body:  &buf,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error Elasticsearch [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		Aggregations map[string]struct {
+			Persons struct {
+				Buckets []struct {
+					Key    string `json:"key"`
+					Person struct {
+						Hits struct {
+							Hits []struct {
+								Source struct {
+									Name string `json:"name"`
+								} `json:"_source"`
+							} `json:"hits"`
+						} `json:"hits"`
+					} `json:"person"`
+					Filmworks struct {
+						IDs struct {
+							Buckets []struct {
+								Key string `json:"key"`
+							} `json:"buckets"`
+						} `json:"ids"`
+						Sample struct {
+							Hits struct {
+								Hits []struct {
+									Source struct {
+										ID     string  `json:"id"`
+										Title  string  `json:"title"`
+										Rating float32 `json:"rating"`
+									} `json:"_source"`
+								} `json:"hits"`
+							} `json:"hits"`
+						} `json:"sample"`
+					} `json:"filmworks"`
+				} `json:"buckets"`
+			} `json:"persons"`
+		} `json:"aggregations"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	// A collaborator credited in several roles, such as a writer-director,
+	// is counted once per shared filmwork.
+	collaborators := make(map[string]*Collaborator)
+	shared := make(map[string]map[string]struct{})
+	for _, role := range roles {
+		for _, bucket := range response.Aggregations[role].Persons.Buckets {
+			c, ok := collaborators[bucket.Key]
+			if !ok {
+				c = &Collaborator{ID: bucket.Key, Roles: []string{}, SampleFilmworks: []*PersonBaseFilmwork{}}
+				if hits := bucket.Person.Hits.Hits; len(hits) > 0 {
+					c.Name = hits[0].Source.Name
+				}
+				collaborators[bucket.Key] = c
+				shared[bucket.Key] = make(map[string]struct{})
+			}
+			c.Roles = append(c.Roles, role)
+			for _, id := range bucket.Filmworks.IDs.Buckets {
+				shared[bucket.Key][id.Key] = struct{}{}
+			}
+			for _, hit := range bucket.Filmworks.Sample.Hits.Hits {
+				if !slices.ContainsFunc(c.SampleFilmworks, func(f *PersonBaseFilmwork) bool { return f.ID == hit.Source.ID }) {
+					c.SampleFilmworks = append(c.SampleFilmworks, &PersonBaseFilmwork{
+						ID:     hit.Source.ID,
+						Title:  hit.Source.Title,
+						Rating: hit.Source.Rating,
+					})
+				}
+			}
+		}
+	}
+
+	result := make([]*Collaborator, 0, len(collaborators))
+	for id, c := range collaborators {
+		c.SharedFilmworks = len(shared[id])
+		slices.SortStableFunc(c.SampleFilmworks, func(a, b *PersonBaseFilmwork) int {
+			if a.Rating != b.Rating {
+				if a.Rating > b.Rating {
+					return -1
+				}
+				return 1
+			}
+			return strings.Compare(a.Title, b.Title)
+		})
+		c.SampleFilmworks = c.SampleFilmworks[:min(len(c.SampleFilmworks), sampleFilmworks)]
+		result = append(result, c)
+	}
+	slices.SortFunc(result, func(a, b *Collaborator) int {
+		if a.SharedFilmworks != b.SharedFilmworks {
+			return b.SharedFilmworks - a.SharedFilmworks
+		}
+		if n := strings.Compare(a.Name, b.Name); n != 0 {
+			return n
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result[:min(len(result), size)], nil
+}
+
+func (r *personRepository) Neighbours(ctx context.Context, ids []string) (map[string]*Neighbourhood, error) {
+	should := make([]map[string]interface{}, 0, len(Roles))
+	for _, role := range Roles {
+		path := rolePaths[role]
+		should = append(should, map[string]interface{}{
+			"nested": map[string]interface{}{
+				"path": path,
+				"query": map[string]interface{}{
+					"terms": map[string]interface{}{
+						path + ".id": ids,
+					},
+				},
+			},
+		})
+	}
+
+	query := map[string]interface{}{
+		"bool": map[string]interface{}{
+			"should": should,
+		},
+	}
+
+	requested := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		requested[id] = struct{}{}
+	}
+
+	type ref struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	neighbourhoods := make(map[string]*Neighbourhood)
+	source := []string{"id", "title", "rating", "actors", "directors", "writers"}
+	err := r.scanner.Scan(ctx, r.indices.Movies, query, source, func(page []json.RawMessage) error {
+		for _, raw := range page {
+			var hit struct {
+				ID        string  `json:"id"`
+				Title     string  `json:"title"`
+				Rating    float32 `json:"rating"`
+				Actors    []ref   `json:"actors"`
+				Directors []ref   `json:"directors"`
+				Writers   []ref   `json:"writers"`
+			}
+			if err := json.Unmarshal(raw, &hit); err != nil {
+				return fmt.Errorf("response parsing error: %w", err)
+			}
+			filmwork := PersonBaseFilmwork{ID: hit.ID, Title: hit.Title, Rating: hit.Rating}
+
+			// A person credited in several roles is one node of the graph.
+			var persons []ref
+			for _, refs := range [][]ref{hit.Actors, hit.Directors, hit.Writers} {
+				for _, p := range refs {
+					if !slices.ContainsFunc(persons, func(q ref) bool { return q.ID == p.ID }) {
+						persons = append(persons, p)
+					}
+				}
+			}
+
+			for _, p := range persons {
+				if _, ok := requested[p.ID]; !ok {
+					continue
+				}
+				n, ok := neighbourhoods[p.ID]
+				if !ok {
+					n = &Neighbourhood{ID: p.ID, Name: p.Name, Edges: []Edge{}}
+					neighbourhoods[p.ID] = n
+				}
+				for _, q := range persons {
+					if q.ID != p.ID {
+						n.Edges = append(n.Edges, Edge{Filmwork: filmwork, Person: BasePerson{ID: q.ID, Name: q.Name}})
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return neighbourhoods, nil
+}
+
+// careerGenres is the number of top genres of a Career.
+const careerGenres = 5
+
+func (r *personRepository) Career(ctx context.Context, personId string) (*Career, error) {
+	roleFilters := make(map[string]interface{}, len(Roles))
+	for _, role := range Roles {
+		roleFilters[role] = roleQuery(personId, role)
+	}
+	roles := map[string]interface{}{
+		"filters": map[string]interface{}{"filters": roleFilters},
+	}
+
+	query := map[string]interface{}{
+		"query": filmworksQuery(personId),
+		"size":  0,
+		"aggs": map[string]interface{}{
+			"first_release": map[string]interface{}{
+				"min": map[string]interface{}{"field": "release_date", "format": "yyyy"},
+			},
+			"last_release": map[string]interface{}{
+				"max": map[string]interface{}{"field": "release_date", "format": "yyyy"},
+			},
+			"roles": roles,
+			"rating": map[string]interface{}{
+				"stats": map[string]interface{}{"field": "rating"},
+			},
+			"best": map[string]interface{}{
+				"top_hits": map[string]interface{}{
+					"size":    1,
+					"sort":    []map[string]interface{}{{"rating": map[string]interface{}{"order": "desc", "missing": "_last"}}},
+					"_source": []string{"id", "title", "rating"},
+				},
+			},
+			"timeline": map[string]interface{}{
+				"date_histogram": map[string]interface{}{
+					"field":             "release_date",
+					"calendar_interval": "year",
+					"format":            "yyyy",
+					"min_doc_count":     1,
+				},
+				"aggs": map[string]interface{}{"roles": roles},
+			},
+			"genres": map[string]interface{}{
+				"terms": map[string]interface{}{"field": "genres", "size": careerGenres},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, fmt.Errorf("request coding error: %w", err)
+	}
+
+	req := esapi.SearchRequest{
+		Index: []string{r.indices.Movies},
+		Body:  &buf,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error Elasticsearch [%d]: %s", resp.StatusCode, body)
+	}
+
+	type roleBuckets struct {
+		Buckets map[string]struct {
+			DocCount int `json:"doc_count"`
+		} `json:"buckets"`
+	}
+	type year struct {
+		ValueAsString string `json:"value_as_string"`
+	}
+	var response struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+		} `json:"hits"`
+		Aggregations struct {
+			FirstRelease year        `json:"first_release"`
+			LastRelease  year        `json:"last_release"`
+			Roles        roleBuckets `json:"roles"`
+			Rating       struct {
+				Avg *float64 `json:"avg"`
+				Max *float64 `json:"max"`
+			} `json:"rating"`
+			Best struct {
+				Hits struct {
+					Hits []struct {
+						Source struct {
+							ID     string  `json:"id"`
+							Title  string  `json:"title"`
+							Rating float32 `json:"rating"`
+						} `json:"_source"`
+					} `json:"hits"`
+				} `json:"hits"`
+			} `json:"best"`
+			Timeline struct {
+				Buckets []struct {
+					KeyAsString string      `json:"key_as_string"`
+					DocCount    int         `json:"doc_count"`
+					Roles       roleBuckets `json:"roles"`
+				} `json:"buckets"`
+			} `json:"timeline"`
+			Genres struct {
+				Buckets []struct {
+					Key      string `json:"key"`
+					DocCount int    `json:"doc_count"`
+				} `json:"buckets"`
+			} `json:"genres"`
+		} `json:"aggregations"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	aggs := response.Aggregations
+	roleCounts := func(b roleBuckets) map[string]int {
+		counts := make(map[string]int, len(Roles))
+		for _, role := range Roles {
+			counts[role] = b.Buckets[role].DocCount
+		}
+		return counts
+	}
+	parseYear := func(s string) *int {
+		y, err := strconv.Atoi(s)
+		if err != nil {
+			return nil
+		}
+		return &y
+	}
+
+	career := &Career{
+		FirstYear:     parseYear(aggs.FirstRelease.ValueAsString),
+		LastYear:      parseYear(aggs.LastRelease.ValueAsString),
+		Filmworks:     response.Hits.Total.Value,
+		Roles:         roleCounts(aggs.Roles),
+		AverageRating: aggs.Rating.Avg,
+		BestRating:    aggs.Rating.Max,
+		Timeline:      make([]CareerYear, 0, len(aggs.Timeline.Buckets)),
+		TopGenres:     make([]GenreCount, 0, len(aggs.Genres.Buckets)),
+	}
+	if hits := aggs.Best.Hits.Hits; len(hits) > 0 && aggs.Rating.Max != nil {
+		career.BestFilmwork = &PersonBaseFilmwork{
+			ID:     hits[0].Source.ID,
+			Title:  hits[0].Source.Title,
+			Rating: hits[0].Source.Rating,
+		}
+	}
+	for _, bucket := range aggs.Timeline.Buckets {
+		y := parseYear(bucket.KeyAsString)
+		if y == nil {
+			continue
+		}
+		career.Timeline = append(career.Timeline, CareerYear{
+			Year:    *y,
+			Credits: bucket.DocCount,
+			Roles:   roleCounts(bucket.Roles),
+		})
+	}
+	for _, bucket := range aggs.Genres.Buckets {
+		career.TopGenres = append(career.TopGenres, GenreCount{Genre: bucket.Key, Count: bucket.DocCount})
+	}
+
+	err = r.scanner.Scan(ctx, r.indices.Movies, filmworksQuery(personId), []string{"id"}, func(page []json.RawMessage) error {
+		for _, raw := range page {
+			var source struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(raw, &source); err != nil {
+				return fmt.Errorf("response parsing error: %w", err)
+			}
+			career.FilmworkIDs = append(career.FilmworkIDs, source.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return career, nil
+}
@@ -3,22 +3,38 @@ package person
 import (
 	"context"
 	"fmt"
+	"log"
+
+	"async-api/internal/config"
 )
 
 type PersonService interface {
 	GetByID(ctx context.Context, id string) (*Person, error)
+	GetByIDs(ctx context.Context, ids []string) (*PersonBatch, error)
 	GetAll(ctx context.Context, page int, size int) ([]*Person, error)
 	Search(ctx context.Context, query string, limit int) ([]*Person, error)
+	// SearchWithSuggestion is Search that suggests a spelling correction when
+	// nothing is found and, with autocorrect, searches for it instead.
+	SearchWithSuggestion(ctx context.Context, query string, limit int, autocorrect bool) (*PersonSearch, error)
 	GetPersonFilmworks(ctx context.Context, id string) ([]*PersonBaseFilmwork, error)
+	// GetCollaborators returns the persons who most often share filmworks
+	// with id, optionally only those credited in role.
+	GetCollaborators(ctx context.Context, id string, role string, size int) ([]*Collaborator, error)
+	// FindPath returns the shortest chain of shared filmworks linking from to
+	// to, or ErrPathNotFound when there is none within the configured depth.
+	FindPath(ctx context.Context, from string, to string) (*PersonPath, error)
+	GetCareer(ctx context.Context, id string) (*Career, error)
 }
 
 type personServiceImpl struct {
 	repo Repository
+	path config.PathConfig
 }
 
-func NewPersonService(repo Repository) PersonService {
+func NewPersonService(repo Repository, path config.PathConfig) PersonService {
 	return &personServiceImpl{
 		repo: repo,
+		path: path,
 	}
 }
 
@@ -46,6 +62,35 @@ func (s *personServiceImpl) Search(ctx context.Context, query string, limit int)
 	return persons, nil
 }
 
+func (s *personServiceImpl) SearchWithSuggestion(ctx context.Context, query string, limit int, autocorrect bool) (*PersonSearch, error) {
+	persons, err := s.Search(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := &PersonSearch{Items: persons}
+	if len(persons) > 0 || query == "" {
+		return result, nil
+	}
+
+	// Suggestions only help, so a failing suggester leaves the result as is.
+	suggestion, err := s.repo.Suggest(ctx, query)
+	if err != nil {
+		log.Printf("failed to suggest person search: %v", err)
+		return result, nil
+	}
+	if suggestion == "" {
+		return result, nil
+	}
+	result.Suggestion = &suggestion
+	if autocorrect {
+		if result.Items, err = s.Search(ctx, suggestion, limit); err != nil {
+			return nil, err
+		}
+		result.Corrected = true
+	}
+	return result, nil
+}
+
 func (s *personServiceImpl) GetPersonFilmworks(ctx context.Context, id string) ([]*PersonBaseFilmwork, error) {
 	filmworks, err := s.repo.Filmworks(ctx, id)
 	if err != nil {
@@ -53,3 +98,38 @@ func (s *personServiceImpl) GetPersonFilmworks(ctx context.Context, id string) (
 	}
 	return filmworks, nil
 }
+
+func (s *personServiceImpl) GetCollaborators(ctx context.Context, id string, role string, size int) ([]*Collaborator, error) {
+	collaborators, err := s.repo.Collaborators(ctx, id, role, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get person collaborators: %w", err)
+	}
+	return collaborators, nil
+}
+
+func (s *personServiceImpl) GetCareer(ctx context.Context, id string) (*Career, error) {
+	career, err := s.repo.Career(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get person career: %w", err)
+	}
+	return career, nil
+}
+
+func (s *personServiceImpl) GetByIDs(ctx context.Context, ids []string) (*PersonBatch, error) {
+	found, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get persons: %w", err)
+	}
+	batch := &PersonBatch{
+		Items:   make([]*Person, 0, len(ids)),
+		Missing: []string{},
+	}
+	for _, id := range ids {
+		if item, ok := found[id]; ok {
+			batch.Items = append(batch.Items, item)
+		} else {
+			batch.Missing = append(batch.Missing, id)
+		}
+	}
+	return batch, nil
+}
@@ -0,0 +1,39 @@
+package rating
+
+import (
+	"context"
+	"fmt"
+
+	"async-api/pkg/cache"
+)
+
+type cachedRepository struct {
+	repo  Repository
+	cache *cache.Cache
+}
+
+func NewCachedRatingRepository(repo Repository, c *cache.Cache) Repository {
+	return &cachedRepository{repo: repo, cache: c}
+}
+
+func (r *cachedRepository) GetByFilmworkIDs(ctx context.Context, ids []string) (map[string]Rating, error) {
+	key := func(id string) string { return "ratings:filmwork:" + id }
+	return cache.FetchMany(ctx, r.cache, ids, key, nil, r.repo.GetByFilmworkIDs)
+}
+
+func (r *cachedRepository) Ranked(ctx context.Context, ascending bool, offset int, limit int) ([]RankedFilmwork, error) {
+	key := fmt.Sprintf("ratings:ranked:%t:%d:%d", ascending, offset, limit)
+	return cache.Fetch(ctx, r.cache, key, func(ctx context.Context) ([]RankedFilmwork, error) {
+		return r.repo.Ranked(ctx, ascending, offset, limit)
+	})
+}
+
+// GetUserScores is not cached: a user expects their own votes to take effect
+// immediately.
+func (r *cachedRepository) GetUserScores(ctx context.Context, userID string, minScore int) (map[string]int, error) {
+	return r.repo.GetUserScores(ctx, userID, minScore)
+}
+
+func (r *cachedRepository) VoteCounts(ctx context.Context) (map[string]int, error) {
+	return r.repo.VoteCounts(ctx)
+}
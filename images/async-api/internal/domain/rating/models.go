@@ -0,0 +1,14 @@
+package rating
+
+// Rating is the audience rating of a filmwork computed from UGC votes.
+// UserRating is nil while nobody has voted.
+type Rating struct {
+	UserRating *float64 `json:"user_rating"`
+	VotesCount int      `json:"votes_count"`
+}
+
+// RankedFilmwork is a filmwork id with its rating, as returned by Ranked.
+type RankedFilmwork struct {
+	ID     string `json:"id"`
+	Rating Rating `json:"rating"`
+}
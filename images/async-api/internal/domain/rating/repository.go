@@ -0,0 +1,205 @@
+package rating
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"async-api/internal/config"
+	"async-api/pkg/database"
+)
+
+type Repository interface {
+	// GetByFilmworkIDs returns the rating of every requested filmwork,
+	// including the ones nobody has voted for.
+	GetByFilmworkIDs(ctx context.Context, ids []string) (map[string]Rating, error)
+	// Ranked returns the voted filmworks ordered by user rating, best first
+	// unless ascending is set.
+	Ranked(ctx context.Context, ascending bool, offset int, limit int) ([]RankedFilmwork, error)
+	// GetUserScores returns the scores of at least minScore that userID gave,
+	// keyed by filmwork id.
+	GetUserScores(ctx context.Context, userID string, minScore int) (map[string]int, error)
+	// VoteCounts returns the number of votes of every voted filmwork.
+	VoteCounts(ctx context.Context) (map[string]int, error)
+}
+
+type ratingRepository struct {
+	filmworks *mongo.Collection
+	timeout   time.Duration
+}
+
+// NewRatingRepository reads votes from the filmworks collection of the UGC
+// database, where documents are keyed by the filmwork UUID stored as binary.
+func NewRatingRepository(client *mongo.Client, cfg config.Config) Repository {
+	return &ratingRepository{
+		filmworks: client.Database(cfg.Mongo.Database).Collection("filmworks"),
+		timeout:   cfg.Mongo.Timeout,
+	}
+}
+
+// ratingStage computes user_rating and votes_count from rating.votes.
+var ratingStage = bson.D{{Key: "$project", Value: bson.D{
+	{Key: "user_rating", Value: bson.D{{Key: "$avg", Value: "$rating.votes.score"}}},
+	{Key: "votes_count", Value: bson.D{{Key: "$size", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$rating.votes", bson.A{}}}}}}},
+}}}
+
+type ratingDoc struct {
+	ID         primitive.Binary `bson:"_id"`
+	UserRating *float64         `bson:"user_rating"`
+	VotesCount int              `bson:"votes_count"`
+}
+
+func (r *ratingRepository) GetByFilmworkIDs(ctx context.Context, ids []string) (map[string]Rating, error) {
+	keys := make(bson.A, 0, len(ids))
+	for _, id := range ids {
+		key, err := database.UUIDToBinary(id)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	ratings := make(map[string]Rating, len(ids))
+	for _, id := range ids {
+		ratings[id] = Rating{}
+	}
+	if len(keys) == 0 {
+		return ratings, nil
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: keys}}}}}},
+		ratingStage,
+	}
+	docs, err := r.aggregate(reqCtx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	for _, doc := range docs {
+		id := database.BinaryToUUID(doc.ID)
+		if _, ok := ratings[id]; ok {
+			ratings[id] = Rating{UserRating: doc.UserRating, VotesCount: doc.VotesCount}
+		}
+	}
+	return ratings, nil
+}
+
+func (r *ratingRepository) Ranked(ctx context.Context, ascending bool, offset int, limit int) ([]RankedFilmwork, error) {
+	order := -1
+	if ascending {
+		order = 1
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "rating.votes.0", Value: bson.D{{Key: "$exists", Value: true}}}}}},
+		ratingStage,
+		{{Key: "$sort", Value: bson.D{
+			{Key: "user_rating", Value: order},
+			{Key: "votes_count", Value: -1},
+			{Key: "_id", Value: 1},
+		}}},
+		{{Key: "$skip", Value: offset}},
+		{{Key: "$limit", Value: limit}},
+	}
+	docs, err := r.aggregate(reqCtx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := make([]RankedFilmwork, 0, len(docs))
+	for _, doc := range docs {
+		ranked = append(ranked, RankedFilmwork{
+			ID:     database.BinaryToUUID(doc.ID),
+			Rating: Rating{UserRating: doc.UserRating, VotesCount: doc.VotesCount},
+		})
+	}
+	return ranked, nil
+}
+
+func (r *ratingRepository) GetUserScores(ctx context.Context, userID string, minScore int) (map[string]int, error) {
+	user, err := database.UUIDToBinary(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	vote := bson.D{
+		{Key: "user_id", Value: user},
+		{Key: "score", Value: bson.D{{Key: "$gte", Value: minScore}}},
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "rating.votes", Value: bson.D{{Key: "$elemMatch", Value: vote}}}}}},
+		{{Key: "$unwind", Value: "$rating.votes"}},
+		{{Key: "$match", Value: bson.D{
+			{Key: "rating.votes.user_id", Value: user},
+			{Key: "rating.votes.score", Value: bson.D{{Key: "$gte", Value: minScore}}},
+		}}},
+		{{Key: "$project", Value: bson.D{{Key: "score", Value: "$rating.votes.score"}}}},
+	}
+	cursor, err := r.filmworks.Aggregate(reqCtx, pipeline, options.Aggregate())
+	if err != nil {
+		return nil, fmt.Errorf("Mongo aggregation error: %w", err)
+	}
+	defer cursor.Close(reqCtx)
+
+	var docs []struct {
+		ID    primitive.Binary `bson:"_id"`
+		Score int              `bson:"score"`
+	}
+	if err := cursor.All(reqCtx, &docs); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	scores := make(map[string]int, len(docs))
+	for _, doc := range docs {
+		scores[database.BinaryToUUID(doc.ID)] = doc.Score
+	}
+	return scores, nil
+}
+
+func (r *ratingRepository) VoteCounts(ctx context.Context) (map[string]int, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "rating.votes.0", Value: bson.D{{Key: "$exists", Value: true}}}}}},
+		ratingStage,
+	}
+	docs, err := r.aggregate(reqCtx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(docs))
+	for _, doc := range docs {
+		counts[database.BinaryToUUID(doc.ID)] = doc.VotesCount
+	}
+	return counts, nil
+}
+
+func (r *ratingRepository) aggregate(ctx context.Context, pipeline mongo.Pipeline) ([]ratingDoc, error) {
+	cursor, err := r.filmworks.Aggregate(ctx, pipeline, options.Aggregate())
+	if err != nil {
+		return nil, fmt.Errorf("Mongo aggregation error: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []ratingDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+	return docs, nil
+}
@@ -0,0 +1,23 @@
+package stats
+
+import (
+	"context"
+
+	"async-api/pkg/cache"
+)
+
+type cachedRepository struct {
+	repo  Repository
+	cache *cache.Cache
+}
+
+// NewCachedStatsRepository serves the statistics from c. The entry is not
+// tagged: it references the whole catalogue, so it expires with the TTL of c
+// instead of being invalidated on every change.
+func NewCachedStatsRepository(repo Repository, c *cache.Cache) Repository {
+	return &cachedRepository{repo: repo, cache: c}
+}
+
+func (r *cachedRepository) Get(ctx context.Context) (*Stats, error) {
+	return cache.Fetch(ctx, r.cache, "stats", r.repo.Get)
+}
@@ -0,0 +1,29 @@
+package stats
+
+import (
+	"net/http"
+
+	"async-api/internal/http"
+	"github.com/gorilla/mux"
+)
+
+type StatsHandler struct {
+	service StatsService
+}
+
+func NewStatsHandler(service StatsService) *StatsHandler {
+	return &StatsHandler{service: service}
+}
+
+func (h *StatsHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/stats", h.Get).Methods("GET")
+}
+
+func (h *StatsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.Get(r.Context())
+	if err != nil {
+		response.SendServiceErrorResponse(w, err)
+		return
+	}
+	response.SendSuccessResponse(w, stats, http.StatusOK)
+}
@@ -0,0 +1,45 @@
+package stats
+
+// Stats are catalogue-wide aggregates. Filmworks without a release date or a
+// rating are left out of the histograms built from them.
+type Stats struct {
+	Totals               Totals         `json:"totals"`
+	FilmworksByType      map[string]int `json:"filmworks_by_type"`
+	FilmworksByAgeRating map[string]int `json:"filmworks_by_age_rating"`
+	FilmworksByDecade    []DecadeCount  `json:"filmworks_by_decade"`
+	RatingHistogram      []RatingBucket `json:"rating_histogram"`
+	ReleaseYearHistogram []YearCount    `json:"release_year_histogram"`
+	TopGenresByCount     []GenreStats   `json:"top_genres_by_count"`
+	TopGenresByRating    []GenreStats   `json:"top_genres_by_rating"`
+}
+
+type Totals struct {
+	Filmworks int `json:"filmworks"`
+	Persons   int `json:"persons"`
+	Genres    int `json:"genres"`
+}
+
+// DecadeCount counts the filmworks released in the decade starting with the
+// year Decade.
+type DecadeCount struct {
+	Decade int `json:"decade"`
+	Count  int `json:"count"`
+}
+
+// RatingBucket counts the filmworks rated from From up to, but excluding, To.
+type RatingBucket struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+type YearCount struct {
+	Year  int `json:"year"`
+	Count int `json:"count"`
+}
+
+type GenreStats struct {
+	Genre         string   `json:"genre"`
+	Count         int      `json:"count"`
+	AverageRating *float64 `json:"average_rating"`
+}
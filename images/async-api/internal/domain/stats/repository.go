@@ -0,0 +1,229 @@
+package stats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/elastic/go-elasticsearch/v9"
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+
+	"async-api/internal/config"
+)
+
+type Repository interface {
+	// Get computes the catalogue statistics with a single multi-search.
+	Get(ctx context.Context) (*Stats, error)
+}
+
+type statsRepository struct {
+	es       *elasticsearch.Client
+	indices  config.ElasticIndicesConfig
+	timeouts config.ElasticTimeoutsConfig
+}
+
+func NewStatsRepository(es *elasticsearch.Client, cfg config.ElasticConfig) Repository {
+	return &statsRepository{es: es, indices: cfg.Indices, timeouts: cfg.Timeouts}
+}
+
+const (
+	// ratingInterval is the width of a rating histogram bucket.
+	ratingInterval = 1
+	// topGenres is the length of the top genre lists.
+	topGenres = 10
+	// minGenreFilmworks keeps genres with only a few filmworks out of the
+	// top genres by rating.
+	minGenreFilmworks = 5
+)
+
+func (r *statsRepository) Get(ctx context.Context) (*Stats, error) {
+	avgRating := map[string]interface{}{
+		"avg_rating": map[string]interface{}{
+			"avg": map[string]interface{}{"field": "rating"},
+		},
+	}
+	movies := map[string]interface{}{
+		"size":             0,
+		"track_total_hits": true,
+		"aggs": map[string]interface{}{
+			"by_type": map[string]interface{}{
+				"terms": map[string]interface{}{"field": "type"},
+			},
+			"by_age_rating": map[string]interface{}{
+				"terms": map[string]interface{}{"field": "age_rating"},
+			},
+			"rating": map[string]interface{}{
+				"histogram": map[string]interface{}{
+					"field":           "rating",
+					"interval":        ratingInterval,
+					"min_doc_count":   0,
+					"extended_bounds": map[string]interface{}{"min": 0, "max": 10 - ratingInterval},
+				},
+			},
+			"release_year": map[string]interface{}{
+				"date_histogram": map[string]interface{}{
+					"field":             "release_date",
+					"calendar_interval": "year",
+					"format":            "yyyy",
+					"min_doc_count":     1,
+				},
+			},
+			"genres_by_count": map[string]interface{}{
+				"terms": map[string]interface{}{"field": "genres", "size": topGenres},
+				"aggs":  avgRating,
+			},
+			"genres_by_rating": map[string]interface{}{
+				"terms": map[string]interface{}{
+					"field":         "genres",
+					"size":          topGenres,
+					"min_doc_count": minGenreFilmworks,
+					"order":         map[string]interface{}{"avg_rating": "desc"},
+				},
+				"aggs": avgRating,
+			},
+		},
+	}
+	count := map[string]interface{}{"size": 0, "track_total_hits": true}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, search := range []struct {
+		index string
+		body  map[string]interface{}
+	}{
+		{r.indices.Movies, movies},
+		{r.indices.Persons, count},
+		{r.indices.Genres, count},
+	} {
+		if err := enc.Encode(map[string]interface{}{"index": search.index}); err != nil {
+			return nil, fmt.Errorf("request coding error: %w", err)
+		}
+		if err := enc.Encode(search.body); err != nil {
+			return nil, fmt.Errorf("request coding error: %w", err)
+		}
+	}
+
+	req := esapi.MsearchRequest{
+		Body: &buf,
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, r.timeouts.Search)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch search error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error Elasticsearch [%d]: %s", resp.StatusCode, body)
+	}
+
+	type terms struct {
+		Buckets []struct {
+			Key       string `json:"key"`
+			DocCount  int    `json:"doc_count"`
+			AvgRating struct {
+				Value *float64 `json:"value"`
+			} `json:"avg_rating"`
+		} `json:"buckets"`
+	}
+	var response struct {
+		Responses []struct {
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+			Hits   struct {
+				Total struct {
+					Value int `json:"value"`
+				} `json:"total"`
+			} `json:"hits"`
+			Aggregations struct {
+				ByType      terms `json:"by_type"`
+				ByAgeRating terms `json:"by_age_rating"`
+				Rating      struct {
+					Buckets []struct {
+						Key      float64 `json:"key"`
+						DocCount int     `json:"doc_count"`
+					} `json:"buckets"`
+				} `json:"rating"`
+				ReleaseYear struct {
+					Buckets []struct {
+						KeyAsString string `json:"key_as_string"`
+						DocCount    int    `json:"doc_count"`
+					} `json:"buckets"`
+				} `json:"release_year"`
+				GenresByCount  terms `json:"genres_by_count"`
+				GenresByRating terms `json:"genres_by_rating"`
+			} `json:"aggregations"`
+		} `json:"responses"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+	if len(response.Responses) != 3 {
+		return nil, fmt.Errorf("response parsing error: expected 3 responses, got %d", len(response.Responses))
+	}
+	for _, res := range response.Responses {
+		if len(res.Error) > 0 {
+			return nil, fmt.Errorf("error Elasticsearch [%d]: %s", res.Status, res.Error)
+		}
+	}
+
+	aggs := response.Responses[0].Aggregations
+	counts := func(t terms) map[string]int {
+		m := make(map[string]int, len(t.Buckets))
+		for _, b := range t.Buckets {
+			m[b.Key] = b.DocCount
+		}
+		return m
+	}
+	genres := func(t terms) []GenreStats {
+		list := make([]GenreStats, 0, len(t.Buckets))
+		for _, b := range t.Buckets {
+			list = append(list, GenreStats{Genre: b.Key, Count: b.DocCount, AverageRating: b.AvgRating.Value})
+		}
+		return list
+	}
+
+	stats := &Stats{
+		Totals: Totals{
+			Filmworks: response.Responses[0].Hits.Total.Value,
+			Persons:   response.Responses[1].Hits.Total.Value,
+			Genres:    response.Responses[2].Hits.Total.Value,
+		},
+		FilmworksByType:      counts(aggs.ByType),
+		FilmworksByAgeRating: counts(aggs.ByAgeRating),
+		FilmworksByDecade:    []DecadeCount{},
+		RatingHistogram:      make([]RatingBucket, 0, len(aggs.Rating.Buckets)),
+		ReleaseYearHistogram: make([]YearCount, 0, len(aggs.ReleaseYear.Buckets)),
+		TopGenresByCount:     genres(aggs.GenresByCount),
+		TopGenresByRating:    genres(aggs.GenresByRating),
+	}
+	for _, b := range aggs.Rating.Buckets {
+		stats.RatingHistogram = append(stats.RatingHistogram, RatingBucket{From: b.Key, To: b.Key + ratingInterval, Count: b.DocCount})
+	}
+	// Decades are summed from the yearly buckets, which come sorted, rather
+	// than aggregated separately: dates have no ten-year calendar interval.
+	for _, b := range aggs.ReleaseYear.Buckets {
+		year, err := strconv.Atoi(b.KeyAsString)
+		if err != nil {
+			return nil, fmt.Errorf("response parsing error: %w", err)
+		}
+		stats.ReleaseYearHistogram = append(stats.ReleaseYearHistogram, YearCount{Year: year, Count: b.DocCount})
+
+		decade := year - year%10
+		if n := len(stats.FilmworksByDecade); n > 0 && stats.FilmworksByDecade[n-1].Decade == decade {
+			stats.FilmworksByDecade[n-1].Count += b.DocCount
+		} else {
+			stats.FilmworksByDecade = append(stats.FilmworksByDecade, DecadeCount{Decade: decade, Count: b.DocCount})
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,28 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+)
+
+type StatsService interface {
+	Get(ctx context.Context) (*Stats, error)
+}
+
+type statsServiceImpl struct {
+	repo Repository
+}
+
+func NewStatsService(repo Repository) StatsService {
+	return &statsServiceImpl{
+		repo: repo,
+	}
+}
+
+func (s *statsServiceImpl) Get(ctx context.Context) (*Stats, error) {
+	stats, err := s.repo.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,102 @@
+package etl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/elastic/go-elasticsearch/v9"
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+type Loader struct {
+	es *elasticsearch.Client
+}
+
+func NewLoader(es *elasticsearch.Client) *Loader {
+	return &Loader{es: es}
+}
+
+// Index writes docs, keyed by id, to index through the _bulk API and fails if
+// any of the items was rejected.
+func Index[T any](ctx context.Context, l *Loader, index string, docs []T, id func(T) string) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, doc := range docs {
+		action := map[string]interface{}{
+			"index": map[string]interface{}{"_index": index, "_id": id(doc)},
+		}
+		if err := encoder.Encode(action); err != nil {
+			return fmt.Errorf("request coding error: %w", err)
+		}
+		if err := encoder.Encode(doc); err != nil {
+			return fmt.Errorf("request coding error: %w", err)
+		}
+	}
+
+	return l.bulk(ctx, &buf)
+}
+
+// Delete removes the documents with ids from index. Documents that are already
+// missing are not treated as an error.
+func (l *Loader) Delete(ctx context.Context, index string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, id := range ids {
+		action := map[string]interface{}{
+			"delete": map[string]interface{}{"_index": index, "_id": id},
+		}
+		if err := encoder.Encode(action); err != nil {
+			return fmt.Errorf("request coding error: %w", err)
+		}
+	}
+
+	return l.bulk(ctx, &buf)
+}
+
+func (l *Loader) bulk(ctx context.Context, body io.Reader) error {
+	req := esapi.BulkRequest{Body: body}
+	resp, err := req.Do(ctx, l.es)
+	if err != nil {
+		return fmt.Errorf("Elasticsearch bulk error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error Elasticsearch [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID     string          `json:"_id"`
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("response parsing error: %w", err)
+	}
+	if !response.Errors {
+		return nil
+	}
+	for _, item := range response.Items {
+		for _, result := range item {
+			if result.Error != nil {
+				return fmt.Errorf("bulk item %s failed [%d]: %s", result.ID, result.Status, result.Error)
+			}
+		}
+	}
+	return fmt.Errorf("bulk request reported errors")
+}
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS content.link_change (
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    film_work_id uuid NOT NULL,
+    changed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS link_change_changed_at_idx
+    ON content.link_change (changed_at, id);
+
+CREATE OR REPLACE FUNCTION content.log_link_change() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO content.link_change (film_work_id) VALUES (OLD.film_work_id);
+    IF TG_OP = 'UPDATE' AND OLD.film_work_id <> NEW.film_work_id THEN
+        INSERT INTO content.link_change (film_work_id) VALUES (NEW.film_work_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER person_film_work_log
+    AFTER UPDATE OR DELETE ON content.person_film_work
+    FOR EACH ROW EXECUTE FUNCTION content.log_link_change();
+
+CREATE OR REPLACE TRIGGER genre_film_work_log
+    AFTER UPDATE OR DELETE ON content.genre_film_work
+    FOR EACH ROW EXECUTE FUNCTION content.log_link_change();
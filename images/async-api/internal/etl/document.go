@@ -0,0 +1,110 @@
+package etl
+
+import "time"
+
+type PersonRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// FilmworkDocument matches the document built by _filmwork_to_document in the
+// admin panel.
+type FilmworkDocument struct {
+	ID             string      `json:"id"`
+	Title          string      `json:"title"`
+	Description    string      `json:"description"`
+	Rating         float64     `json:"rating"`
+	ReleaseDate    *string     `json:"release_date"`
+	Type           string      `json:"type"`
+	AgeRating      string      `json:"age_rating"`
+	AccessType     string      `json:"access_type"`
+	Genres         []string    `json:"genres"`
+	Actors         []PersonRef `json:"actors"`
+	Directors      []PersonRef `json:"directors"`
+	Writers        []PersonRef `json:"writers"`
+	ActorsNames    []string    `json:"actors_names"`
+	DirectorsNames []string    `json:"directors_names"`
+	WritersNames   []string    `json:"writers_names"`
+	Modified       time.Time   `json:"modified"`
+	// GenreIDs identify Genres for cache invalidation; they are not indexed.
+	GenreIDs []string `json:"-"`
+}
+
+type PersonDocument struct {
+	ID       string    `json:"id"`
+	FullName string    `json:"full_name"`
+	Modified time.Time `json:"modified"`
+}
+
+type GenreDocument struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Modified    time.Time `json:"modified"`
+}
+
+type filmworkRow struct {
+	ID          string
+	Title       string
+	Description string
+	Rating      *float64
+	ReleaseDate *time.Time
+	Type        string
+	AgeRating   string
+	AccessType  string
+	Genres      []string
+	GenreIDs    []string
+	Persons     []personRoleRow
+}
+
+type personRoleRow struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+func newFilmworkDocument(row filmworkRow) FilmworkDocument {
+	doc := FilmworkDocument{
+		ID:             row.ID,
+		Title:          row.Title,
+		Description:    row.Description,
+		Type:           row.Type,
+		AgeRating:      row.AgeRating,
+		AccessType:     row.AccessType,
+		Genres:         row.Genres,
+		GenreIDs:       row.GenreIDs,
+		Actors:         []PersonRef{},
+		Directors:      []PersonRef{},
+		Writers:        []PersonRef{},
+		ActorsNames:    []string{},
+		DirectorsNames: []string{},
+		WritersNames:   []string{},
+		Modified:       time.Now().UTC(),
+	}
+	if doc.Genres == nil {
+		doc.Genres = []string{}
+	}
+	if row.Rating != nil {
+		doc.Rating = *row.Rating
+	}
+	if row.ReleaseDate != nil {
+		date := row.ReleaseDate.Format(time.DateOnly)
+		doc.ReleaseDate = &date
+	}
+
+	for _, p := range row.Persons {
+		ref := PersonRef{ID: p.ID, Name: p.Name}
+		switch p.Role {
+		case "actor":
+			doc.Actors = append(doc.Actors, ref)
+			doc.ActorsNames = append(doc.ActorsNames, p.Name)
+		case "director":
+			doc.Directors = append(doc.Directors, ref)
+			doc.DirectorsNames = append(doc.DirectorsNames, p.Name)
+		case "writer":
+			doc.Writers = append(doc.Writers, ref)
+			doc.WritersNames = append(doc.WritersNames, p.Name)
+		}
+	}
+	return doc
+}
@@ -0,0 +1,233 @@
+package etl
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"async-api/internal/config"
+	"async-api/pkg/cache"
+)
+
+// Pipeline moves rows changed in Postgres since the last checkpoint into
+// Elasticsearch. Person and genre changes are cascaded into the filmworks that
+// reference them. Links between filmworks and persons or genres are read by
+// created_at, and their updates and deletions from the link_change log, to
+// rebuild the filmworks they belong to. Checkpoints are saved only after a
+// batch has been loaded, so an interrupted run resumes from the last loaded
+// batch. Deleted filmworks, persons and genres are not detected since they
+// leave no updated_at behind.
+type Pipeline struct {
+	extractor *Extractor
+	loader    *Loader
+	state     *State
+	indices   config.ElasticIndicesConfig
+	batchSize int
+	notifier  Notifier
+}
+
+// Notifier is told about every entity the pipeline has written or deleted.
+type Notifier interface {
+	Notify(ctx context.Context, entity string, ids []string) error
+}
+
+// NewPipeline builds a pipeline; notifier may be nil.
+func NewPipeline(extractor *Extractor, loader *Loader, state *State, indices config.ElasticIndicesConfig, batchSize int, notifier Notifier) *Pipeline {
+	return &Pipeline{
+		extractor: extractor,
+		loader:    loader,
+		state:     state,
+		indices:   indices,
+		batchSize: batchSize,
+		notifier:  notifier,
+	}
+}
+
+func (p *Pipeline) Run(ctx context.Context) error {
+	if err := p.process(ctx, "genre", p.loadGenres); err != nil {
+		return err
+	}
+	if err := p.process(ctx, "person", p.loadPersons); err != nil {
+		return err
+	}
+	if err := p.process(ctx, "film_work", p.LoadFilmworks); err != nil {
+		return err
+	}
+	for _, links := range []struct{ table, column string }{
+		{"person_film_work", "created_at"},
+		{"genre_film_work", "created_at"},
+		{"link_change", "changed_at"},
+	} {
+		if err := p.processLinks(ctx, links.table, links.column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Pipeline) process(ctx context.Context, table string, load func(ctx context.Context, ids []string) error) error {
+	for {
+		checkpoint := p.state.Get(table)
+		changed, err := p.extractor.Changed(ctx, table, checkpoint, p.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+
+		ids := make([]string, 0, len(changed))
+		for _, row := range changed {
+			ids = append(ids, row.ID)
+		}
+		if err := load(ctx, ids); err != nil {
+			return fmt.Errorf("failed to load %s: %w", table, err)
+		}
+
+		last := changed[len(changed)-1]
+		if err := p.state.Save(table, Checkpoint{UpdatedAt: last.UpdatedAt, ID: last.ID}); err != nil {
+			return err
+		}
+		log.Printf("loaded %d %s rows up to %s", len(changed), table, last.UpdatedAt)
+
+		if len(changed) < p.batchSize {
+			return nil
+		}
+	}
+}
+
+// processLinks rebuilds the filmworks of the rows of a link table changed
+// since its checkpoint. Loaded link_change rows are pruned.
+func (p *Pipeline) processLinks(ctx context.Context, table string, column string) error {
+	for {
+		checkpoint := p.state.Get(table)
+		changed, err := p.extractor.ChangedLinks(ctx, table, column, checkpoint, p.batchSize)
+		if err != nil {
+			return err
+		}
+		if len(changed) == 0 {
+			return nil
+		}
+
+		filmworks := make(map[string]struct{}, len(changed))
+		for _, row := range changed {
+			filmworks[row.FilmworkID] = struct{}{}
+		}
+		if err := p.LoadFilmworks(ctx, keys(filmworks)); err != nil {
+			return fmt.Errorf("failed to load %s: %w", table, err)
+		}
+
+		last := changed[len(changed)-1]
+		checkpoint = Checkpoint{UpdatedAt: last.ChangedAt, ID: last.ID}
+		if err := p.state.Save(table, checkpoint); err != nil {
+			return err
+		}
+		if table == "link_change" {
+			if err := p.extractor.PruneLinkChanges(ctx, checkpoint); err != nil {
+				return err
+			}
+		}
+		log.Printf("loaded %d %s rows up to %s", len(changed), table, last.ChangedAt)
+
+		if len(changed) < p.batchSize {
+			return nil
+		}
+	}
+}
+
+func (p *Pipeline) loadGenres(ctx context.Context, ids []string) error {
+	if err := p.indexGenres(ctx, ids); err != nil {
+		return err
+	}
+	filmworkIDs, err := p.extractor.FilmworkIDsByGenres(ctx, ids)
+	if err != nil {
+		return err
+	}
+	return p.LoadFilmworks(ctx, filmworkIDs)
+}
+
+func (p *Pipeline) loadPersons(ctx context.Context, ids []string) error {
+	if err := p.indexPersons(ctx, ids); err != nil {
+		return err
+	}
+	filmworkIDs, err := p.extractor.FilmworkIDsByPersons(ctx, ids)
+	if err != nil {
+		return err
+	}
+	return p.LoadFilmworks(ctx, filmworkIDs)
+}
+
+func (p *Pipeline) indexGenres(ctx context.Context, ids []string) error {
+	docs, err := p.extractor.Genres(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if err := Index(ctx, p.loader, p.indices.Genres, docs, func(d GenreDocument) string { return d.ID }); err != nil {
+		return err
+	}
+	return p.notify(ctx, cache.EntityGenre, ids)
+}
+
+func (p *Pipeline) indexPersons(ctx context.Context, ids []string) error {
+	docs, err := p.extractor.Persons(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if err := Index(ctx, p.loader, p.indices.Persons, docs, func(d PersonDocument) string { return d.ID }); err != nil {
+		return err
+	}
+	return p.notify(ctx, cache.EntityPerson, ids)
+}
+
+// LoadFilmworks rebuilds and indexes the filmwork documents for ids in
+// batches. The persons and genres of the rebuilt filmworks are announced too:
+// their cached entries list filmworks, and a newly linked filmwork is not
+// among the tags of those entries yet.
+func (p *Pipeline) LoadFilmworks(ctx context.Context, ids []string) error {
+	for start := 0; start < len(ids); start += p.batchSize {
+		end := min(start+p.batchSize, len(ids))
+		docs, err := p.extractor.Filmworks(ctx, ids[start:end])
+		if err != nil {
+			return err
+		}
+		if err := Index(ctx, p.loader, p.indices.Movies, docs, func(d FilmworkDocument) string { return d.ID }); err != nil {
+			return err
+		}
+		if err := p.notify(ctx, cache.EntityFilmwork, ids[start:end]); err != nil {
+			return err
+		}
+		persons, genres := linkedEntities(docs)
+		if err := p.notify(ctx, cache.EntityPerson, persons); err != nil {
+			return err
+		}
+		if err := p.notify(ctx, cache.EntityGenre, genres); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkedEntities returns the distinct persons and genres credited in docs.
+func linkedEntities(docs []FilmworkDocument) ([]string, []string) {
+	var persons, genres []string
+	for _, doc := range docs {
+		for _, ref := range slices.Concat(doc.Actors, doc.Directors, doc.Writers) {
+			persons = append(persons, ref.ID)
+		}
+		genres = append(genres, doc.GenreIDs...)
+	}
+	slices.Sort(persons)
+	slices.Sort(genres)
+	return slices.Compact(persons), slices.Compact(genres)
+}
+
+func (p *Pipeline) notify(ctx context.Context, entity string, ids []string) error {
+	if p.notifier == nil || len(ids) == 0 {
+		return nil
+	}
+	if err := p.notifier.Notify(ctx, entity, ids); err != nil {
+		return fmt.Errorf("failed to notify %s changes: %w", entity, err)
+	}
+	return nil
+}
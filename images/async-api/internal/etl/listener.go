@@ -0,0 +1,285 @@
+package etl
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"async-api/pkg/cache"
+)
+
+const notifyChannel = "content_changes"
+
+//go:embed notify.sql
+var notifySQL string
+
+type changeEvent struct {
+	Table string `json:"table"`
+	Op    string `json:"op"`
+	ID    string `json:"id"`
+}
+
+// changeSet collects the ids touched by a burst of notifications so that a
+// filmwork referenced by several changed rows is rebuilt only once.
+type changeSet struct {
+	filmworks        map[string]struct{}
+	persons          map[string]struct{}
+	genres           map[string]struct{}
+	deletedFilmworks map[string]struct{}
+	deletedPersons   map[string]struct{}
+	deletedGenres    map[string]struct{}
+}
+
+func newChangeSet() *changeSet {
+	return &changeSet{
+		filmworks:        map[string]struct{}{},
+		persons:          map[string]struct{}{},
+		genres:           map[string]struct{}{},
+		deletedFilmworks: map[string]struct{}{},
+		deletedPersons:   map[string]struct{}{},
+		deletedGenres:    map[string]struct{}{},
+	}
+}
+
+func (s *changeSet) add(event changeEvent) {
+	deleted := event.Op == "DELETE"
+	switch event.Table {
+	case "film_work":
+		if deleted {
+			s.deletedFilmworks[event.ID] = struct{}{}
+			delete(s.filmworks, event.ID)
+		} else {
+			s.filmworks[event.ID] = struct{}{}
+			delete(s.deletedFilmworks, event.ID)
+		}
+	case "person_film_work", "genre_film_work":
+		if _, ok := s.deletedFilmworks[event.ID]; !ok {
+			s.filmworks[event.ID] = struct{}{}
+		}
+	case "person":
+		if deleted {
+			s.deletedPersons[event.ID] = struct{}{}
+			delete(s.persons, event.ID)
+		} else {
+			s.persons[event.ID] = struct{}{}
+			delete(s.deletedPersons, event.ID)
+		}
+	case "genre":
+		if deleted {
+			s.deletedGenres[event.ID] = struct{}{}
+			delete(s.genres, event.ID)
+		} else {
+			s.genres[event.ID] = struct{}{}
+			delete(s.deletedGenres, event.ID)
+		}
+	}
+}
+
+func (s *changeSet) empty() bool {
+	return len(s.filmworks)+len(s.persons)+len(s.genres)+
+		len(s.deletedFilmworks)+len(s.deletedPersons)+len(s.deletedGenres) == 0
+}
+
+// Listener applies Postgres changes to Elasticsearch as they happen. Triggers
+// on the content tables publish every modified row over NOTIFY; the listener
+// batches the events for the debounce window from the first buffered event
+// and then reindexes only the affected documents, cascading person and genre
+// changes into the filmworks that reference them. Notifications sent while
+// the listener is disconnected are lost, so every (re)connect is followed by a
+// checkpoint-based catch-up run. Changes that failed to apply are kept and
+// retried after the catch-up, since deletions cannot be caught up on.
+type Listener struct {
+	db       *pgxpool.Pool
+	pipeline *Pipeline
+	debounce time.Duration
+	pending  *changeSet
+}
+
+func NewListener(db *pgxpool.Pool, pipeline *Pipeline, debounce time.Duration) *Listener {
+	return &Listener{
+		db:       db,
+		pipeline: pipeline,
+		debounce: debounce,
+		pending:  newChangeSet(),
+	}
+}
+
+// InstallTriggers creates or replaces the NOTIFY triggers on the content
+// tables.
+func (l *Listener) InstallTriggers(ctx context.Context) error {
+	if _, err := l.db.Exec(ctx, notifySQL); err != nil {
+		return fmt.Errorf("failed to install notify triggers: %w", err)
+	}
+	return nil
+}
+
+// Run listens until ctx is cancelled, reconnecting after connection errors.
+func (l *Listener) Run(ctx context.Context) error {
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		log.Printf("CDC listener stopped: %v, reconnecting", err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (l *Listener) listen(ctx context.Context) error {
+	conn, err := l.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	if err := l.pipeline.Run(ctx); err != nil {
+		return fmt.Errorf("catch-up run failed: %w", err)
+	}
+
+	if err := l.flush(ctx); err != nil {
+		return err
+	}
+
+	// The window is fixed when the first change is buffered, so a steady
+	// stream of notifications cannot postpone applying them.
+	var deadline time.Time
+	for {
+		waitCtx := ctx
+		cancel := context.CancelFunc(func() {})
+		if !l.pending.empty() {
+			waitCtx, cancel = context.WithDeadline(ctx, deadline)
+		}
+		notification, err := conn.Conn().WaitForNotification(waitCtx)
+		cancel()
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if waitCtx.Err() == nil {
+				return fmt.Errorf("failed to wait for notification: %w", err)
+			}
+			if err := l.flush(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var event changeEvent
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Printf("skipping malformed change event %q: %v", notification.Payload, err)
+			continue
+		}
+		if l.pending.empty() {
+			deadline = time.Now().Add(l.debounce)
+		}
+		l.pending.add(event)
+	}
+}
+
+// flush applies the pending changes. They are kept when applying fails, to
+// be retried on the next flush.
+func (l *Listener) flush(ctx context.Context) error {
+	if l.pending.empty() {
+		return nil
+	}
+	if err := l.apply(ctx, l.pending); err != nil {
+		return err
+	}
+	l.pending = newChangeSet()
+	return nil
+}
+
+func (l *Listener) apply(ctx context.Context, changes *changeSet) error {
+	p := l.pipeline
+
+	filmworks := maps.Clone(changes.filmworks)
+	if len(changes.persons) > 0 {
+		ids := keys(changes.persons)
+		if err := p.indexPersons(ctx, ids); err != nil {
+			return err
+		}
+		affected, err := p.extractor.FilmworkIDsByPersons(ctx, ids)
+		if err != nil {
+			return err
+		}
+		addAll(filmworks, affected)
+	}
+	if len(changes.genres) > 0 {
+		ids := keys(changes.genres)
+		if err := p.indexGenres(ctx, ids); err != nil {
+			return err
+		}
+		affected, err := p.extractor.FilmworkIDsByGenres(ctx, ids)
+		if err != nil {
+			return err
+		}
+		addAll(filmworks, affected)
+	}
+	for id := range changes.deletedFilmworks {
+		delete(filmworks, id)
+	}
+
+	if err := p.LoadFilmworks(ctx, keys(filmworks)); err != nil {
+		return err
+	}
+	deletions := []struct {
+		index  string
+		entity string
+		ids    []string
+	}{
+		{p.indices.Movies, cache.EntityFilmwork, keys(changes.deletedFilmworks)},
+		{p.indices.Persons, cache.EntityPerson, keys(changes.deletedPersons)},
+		{p.indices.Genres, cache.EntityGenre, keys(changes.deletedGenres)},
+	}
+	for _, d := range deletions {
+		if err := p.loader.Delete(ctx, d.index, d.ids); err != nil {
+			return err
+		}
+		if err := p.notify(ctx, d.entity, d.ids); err != nil {
+			return err
+		}
+	}
+
+	// The link deletions were applied from their notifications already;
+	// draining the link_change log keeps it from growing while listening.
+	if err := p.processLinks(ctx, "link_change", "changed_at"); err != nil {
+		return err
+	}
+
+	log.Printf(
+		"applied changes: %d filmworks, %d persons, %d genres, %d deletions",
+		len(filmworks), len(changes.persons), len(changes.genres),
+		len(changes.deletedFilmworks)+len(changes.deletedPersons)+len(changes.deletedGenres),
+	)
+	return nil
+}
+
+func keys(set map[string]struct{}) []string {
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func addAll(set map[string]struct{}, ids []string) {
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+}
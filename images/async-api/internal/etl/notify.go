@@ -0,0 +1,28 @@
+package etl
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+
+	"async-api/pkg/cache"
+)
+
+// RedisNotifier publishes loaded entities to the async-api cache invalidation
+// channel.
+type RedisNotifier struct {
+	client  *redis.Client
+	channel string
+}
+
+func NewRedisNotifier(client *redis.Client, channel string) *RedisNotifier {
+	return &RedisNotifier{client: client, channel: channel}
+}
+
+func (n *RedisNotifier) Notify(ctx context.Context, entity string, ids []string) error {
+	events := make([]cache.Event, 0, len(ids))
+	for _, id := range ids {
+		events = append(events, cache.Event{Entity: entity, ID: id})
+	}
+	return cache.Publish(ctx, n.client, n.channel, events...)
+}
@@ -0,0 +1,53 @@
+CREATE OR REPLACE FUNCTION content.notify_content_change() RETURNS trigger AS $$
+DECLARE
+    rec RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        rec := OLD;
+    ELSE
+        rec := NEW;
+    END IF;
+
+    IF TG_TABLE_NAME IN ('person_film_work', 'genre_film_work') THEN
+        PERFORM pg_notify('content_changes', json_build_object(
+            'table', TG_TABLE_NAME,
+            'op', TG_OP,
+            'id', rec.film_work_id
+        )::text);
+        IF TG_OP = 'UPDATE' AND OLD.film_work_id <> NEW.film_work_id THEN
+            PERFORM pg_notify('content_changes', json_build_object(
+                'table', TG_TABLE_NAME,
+                'op', TG_OP,
+                'id', OLD.film_work_id
+            )::text);
+        END IF;
+    ELSE
+        PERFORM pg_notify('content_changes', json_build_object(
+            'table', TG_TABLE_NAME,
+            'op', TG_OP,
+            'id', rec.id
+        )::text);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER film_work_notify
+    AFTER INSERT OR UPDATE OR DELETE ON content.film_work
+    FOR EACH ROW EXECUTE FUNCTION content.notify_content_change();
+
+CREATE OR REPLACE TRIGGER person_notify
+    AFTER INSERT OR UPDATE OR DELETE ON content.person
+    FOR EACH ROW EXECUTE FUNCTION content.notify_content_change();
+
+CREATE OR REPLACE TRIGGER genre_notify
+    AFTER INSERT OR UPDATE OR DELETE ON content.genre
+    FOR EACH ROW EXECUTE FUNCTION content.notify_content_change();
+
+CREATE OR REPLACE TRIGGER person_film_work_notify
+    AFTER INSERT OR UPDATE OR DELETE ON content.person_film_work
+    FOR EACH ROW EXECUTE FUNCTION content.notify_content_change();
+
+CREATE OR REPLACE TRIGGER genre_film_work_notify
+    AFTER INSERT OR UPDATE OR DELETE ON content.genre_film_work
+    FOR EACH ROW EXECUTE FUNCTION content.notify_content_change();
@@ -0,0 +1,239 @@
+package etl
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type changedRow struct {
+	ID        string
+	UpdatedAt time.Time
+}
+
+// linkRow is a row of a link table, or of the link_change log of their
+// updates and deletions, with the filmwork it belongs to.
+type linkRow struct {
+	ID         string
+	ChangedAt  time.Time
+	FilmworkID string
+}
+
+//go:embed changelog.sql
+var changelogSQL string
+
+type Extractor struct {
+	db *pgxpool.Pool
+}
+
+func NewExtractor(db *pgxpool.Pool) *Extractor {
+	return &Extractor{db: db}
+}
+
+// Changed returns up to limit rows of table modified after the checkpoint,
+// ordered by (updated_at, id).
+func (e *Extractor) Changed(ctx context.Context, table string, after Checkpoint, limit int) ([]changedRow, error) {
+	query := fmt.Sprintf(`
+		SELECT id::text, updated_at
+		FROM content.%s
+		WHERE (updated_at, id) > ($1, $2::text::uuid)
+		ORDER BY updated_at, id
+		LIMIT $3`, table)
+
+	rows, err := e.db.Query(ctx, query, after.UpdatedAt, after.ID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	changed := make([]changedRow, 0, limit)
+	for rows.Next() {
+		var row changedRow
+		if err := rows.Scan(&row.ID, &row.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+		changed = append(changed, row)
+	}
+	return changed, rows.Err()
+}
+
+// InstallChangeLog creates the link_change table and the triggers logging the
+// updates and deletions of link rows into it, which leave no created_at
+// behind to find them by.
+func (e *Extractor) InstallChangeLog(ctx context.Context) error {
+	if _, err := e.db.Exec(ctx, changelogSQL); err != nil {
+		return fmt.Errorf("failed to install change log: %w", err)
+	}
+	return nil
+}
+
+// ChangedLinks returns up to limit rows of the link table added after the
+// checkpoint, ordered by (column, id).
+func (e *Extractor) ChangedLinks(ctx context.Context, table string, column string, after Checkpoint, limit int) ([]linkRow, error) {
+	query := fmt.Sprintf(`
+		SELECT id::text, %[2]s, film_work_id::text
+		FROM content.%[1]s
+		WHERE (%[2]s, id) > ($1, $2::text::uuid)
+		ORDER BY %[2]s, id
+		LIMIT $3`, table, column)
+
+	rows, err := e.db.Query(ctx, query, after.UpdatedAt, after.ID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	changed := make([]linkRow, 0, limit)
+	for rows.Next() {
+		var row linkRow
+		if err := rows.Scan(&row.ID, &row.ChangedAt, &row.FilmworkID); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+		changed = append(changed, row)
+	}
+	return changed, rows.Err()
+}
+
+// PruneLinkChanges deletes the link_change rows up to the checkpoint, which
+// have been loaded already.
+func (e *Extractor) PruneLinkChanges(ctx context.Context, upTo Checkpoint) error {
+	_, err := e.db.Exec(ctx, `
+		DELETE FROM content.link_change
+		WHERE (changed_at, id) <= ($1, $2::text::uuid)`, upTo.UpdatedAt, upTo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to prune link_change: %w", err)
+	}
+	return nil
+}
+
+// FilmworkIDsByPersons returns the filmworks the given persons take part in.
+func (e *Extractor) FilmworkIDsByPersons(ctx context.Context, personIDs []string) ([]string, error) {
+	return e.ids(ctx, `
+		SELECT DISTINCT film_work_id::text
+		FROM content.person_film_work
+		WHERE person_id = ANY($1::text[]::uuid[])`, personIDs)
+}
+
+// FilmworkIDsByGenres returns the filmworks tagged with the given genres.
+func (e *Extractor) FilmworkIDsByGenres(ctx context.Context, genreIDs []string) ([]string, error) {
+	return e.ids(ctx, `
+		SELECT DISTINCT film_work_id::text
+		FROM content.genre_film_work
+		WHERE genre_id = ANY($1::text[]::uuid[])`, genreIDs)
+}
+
+func (e *Extractor) ids(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := e.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query filmwork ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan filmwork id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (e *Extractor) Filmworks(ctx context.Context, ids []string) ([]FilmworkDocument, error) {
+	rows, err := e.db.Query(ctx, `
+		SELECT
+			fw.id::text,
+			fw.title,
+			fw.description,
+			fw.rating,
+			fw.release_date,
+			fw.type,
+			fw.age_rating,
+			fw.access_type,
+			COALESCE(array_agg(DISTINCT g.name) FILTER (WHERE g.id IS NOT NULL), '{}'),
+			COALESCE(array_agg(DISTINCT g.id::text) FILTER (WHERE g.id IS NOT NULL), '{}'),
+			COALESCE(
+				jsonb_agg(DISTINCT jsonb_build_object('id', p.id, 'name', p.full_name, 'role', pfw.role))
+					FILTER (WHERE p.id IS NOT NULL),
+				'[]'
+			)
+		FROM content.film_work fw
+		LEFT JOIN content.person_film_work pfw ON pfw.film_work_id = fw.id
+		LEFT JOIN content.person p ON p.id = pfw.person_id
+		LEFT JOIN content.genre_film_work gfw ON gfw.film_work_id = fw.id
+		LEFT JOIN content.genre g ON g.id = gfw.genre_id
+		WHERE fw.id = ANY($1::text[]::uuid[])
+		GROUP BY fw.id`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query filmworks: %w", err)
+	}
+	defer rows.Close()
+
+	docs := make([]FilmworkDocument, 0, len(ids))
+	for rows.Next() {
+		var row filmworkRow
+		if err := rows.Scan(
+			&row.ID,
+			&row.Title,
+			&row.Description,
+			&row.Rating,
+			&row.ReleaseDate,
+			&row.Type,
+			&row.AgeRating,
+			&row.AccessType,
+			&row.Genres,
+			&row.GenreIDs,
+			&row.Persons,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan filmwork: %w", err)
+		}
+		docs = append(docs, newFilmworkDocument(row))
+	}
+	return docs, rows.Err()
+}
+
+func (e *Extractor) Persons(ctx context.Context, ids []string) ([]PersonDocument, error) {
+	rows, err := e.db.Query(ctx, `
+		SELECT id::text, full_name
+		FROM content.person
+		WHERE id = ANY($1::text[]::uuid[])`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query persons: %w", err)
+	}
+	defer rows.Close()
+
+	docs := make([]PersonDocument, 0, len(ids))
+	for rows.Next() {
+		doc := PersonDocument{Modified: time.Now().UTC()}
+		if err := rows.Scan(&doc.ID, &doc.FullName); err != nil {
+			return nil, fmt.Errorf("failed to scan person: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, rows.Err()
+}
+
+func (e *Extractor) Genres(ctx context.Context, ids []string) ([]GenreDocument, error) {
+	rows, err := e.db.Query(ctx, `
+		SELECT id::text, name, COALESCE(description, '')
+		FROM content.genre
+		WHERE id = ANY($1::text[]::uuid[])`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query genres: %w", err)
+	}
+	defer rows.Close()
+
+	docs := make([]GenreDocument, 0, len(ids))
+	for rows.Next() {
+		doc := GenreDocument{Modified: time.Now().UTC()}
+		if err := rows.Scan(&doc.ID, &doc.Name, &doc.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan genre: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, rows.Err()
+}
@@ -0,0 +1,84 @@
+package etl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Checkpoint is the position of the last row loaded from a table, ordered by
+// (updated_at, id).
+type Checkpoint struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	ID        string    `json:"id"`
+}
+
+// State keeps checkpoints in a JSON file. The file is replaced atomically on
+// every save so that a crash never leaves a partially written state behind.
+type State struct {
+	path string
+
+	mu          sync.Mutex
+	checkpoints map[string]Checkpoint
+}
+
+func LoadState(path string) (*State, error) {
+	s := &State{path: path, checkpoints: map[string]Checkpoint{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.checkpoints); err != nil {
+		return nil, fmt.Errorf("failed to parse state %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *State) Get(key string) Checkpoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.checkpoints[key]
+	if !ok {
+		return Checkpoint{ID: "00000000-0000-0000-0000-000000000000"}
+	}
+	return c
+}
+
+func (s *State) Save(key string, c Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[key] = c
+
+	data, err := json.MarshalIndent(s.checkpoints, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	return nil
+}
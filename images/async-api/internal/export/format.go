@@ -0,0 +1,84 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+)
+
+var contentTypes = map[string]string{
+	FormatNDJSON: "application/x-ndjson",
+	FormatCSV:    "text/csv; charset=utf-8",
+}
+
+// negotiateFormat picks the output format from the format parameter or, if
+// it is absent, from the Accept header. NDJSON is the default.
+func negotiateFormat(r *http.Request) (string, bool) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		_, ok := contentTypes[format]
+		return format, ok
+	}
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "text/csv":
+			return FormatCSV, true
+		case "application/x-ndjson", "application/jsonl", "application/json":
+			return FormatNDJSON, true
+		}
+	}
+	return FormatNDJSON, true
+}
+
+type encoder interface {
+	header(columns []string) error
+	write(record interface{}, row []string) error
+	flush() error
+}
+
+func newEncoder(format string, w io.Writer) encoder {
+	if format == FormatCSV {
+		return &csvEncoder{w: csv.NewWriter(w)}
+	}
+	return &ndjsonEncoder{enc: json.NewEncoder(w)}
+}
+
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) header([]string) error { return nil }
+
+func (e *ndjsonEncoder) write(record interface{}, _ []string) error {
+	return e.enc.Encode(record)
+}
+
+func (e *ndjsonEncoder) flush() error { return nil }
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) header(columns []string) error {
+	return e.w.Write(columns)
+}
+
+func (e *csvEncoder) write(_ interface{}, row []string) error {
+	return e.w.Write(row)
+}
+
+func (e *csvEncoder) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
@@ -0,0 +1,110 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"async-api/internal/auth"
+	"async-api/internal/config"
+	"async-api/internal/domain/filmwork"
+	"async-api/internal/http"
+	"async-api/internal/index"
+)
+
+type ExportHandler struct {
+	scanner *index.Scanner
+	indices config.ElasticIndicesConfig
+	tokens  []string
+}
+
+func NewExportHandler(scanner *index.Scanner, cfg config.Config) *ExportHandler {
+	return &ExportHandler{scanner: scanner, indices: cfg.Elastic.Indices, tokens: cfg.Export.Tokens}
+}
+
+func (h *ExportHandler) RegisterRoutes(router *mux.Router) {
+	export := auth.RequireServiceToken(h.tokens)(http.HandlerFunc(h.Export))
+	router.Handle("/export/{kind:filmworks|persons|genres}", export).Methods("GET")
+}
+
+// Export streams every matching document of the requested kind. Each page is
+// flushed to the client as soon as it is encoded, so memory use does not grow
+// with the size of the catalogue.
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	k := kinds[mux.Vars(r)["kind"]]
+	format, ok := negotiateFormat(r)
+	if !ok {
+		response.SendErrorResponse(w, "Неверный формат format", http.StatusBadRequest)
+		return
+	}
+	query, err := k.query(r)
+	if err != nil {
+		var queryErr *filmwork.QueryError
+		if errors.As(err, &queryErr) {
+			response.SendSyntaxErrorResponse(w, fmt.Sprintf("Неверный формат q на позиции %d: %s", queryErr.Pos, queryErr.Msg), queryErr.Pos)
+			return
+		}
+		response.SendErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// An export outlives the server write timeout; the point in time and the
+	// per-page search timeout bound it instead.
+	controller := http.NewResponseController(w)
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("export: failed to clear write deadline: %v", err)
+	}
+
+	w.Header().Set("Content-Type", contentTypes[format])
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, mux.Vars(r)["kind"], format))
+	w.Header().Set("Cache-Control", "no-store")
+
+	enc := newEncoder(format, w)
+	started := false
+	err = h.scanner.Scan(r.Context(), k.index(h.indices), query, nil, func(page []json.RawMessage) error {
+		if !started {
+			started = true
+			w.WriteHeader(http.StatusOK)
+			if err := enc.header(k.columns); err != nil {
+				return err
+			}
+		}
+		for _, source := range page {
+			record, row, err := k.decode(source)
+			if err != nil {
+				return fmt.Errorf("response parsing error: %w", err)
+			}
+			if err := enc.write(record, row); err != nil {
+				return err
+			}
+		}
+		if err := enc.flush(); err != nil {
+			return err
+		}
+		return controller.Flush()
+	})
+
+	if !started {
+		if err != nil {
+			w.Header().Del("Content-Disposition")
+			response.SendServiceErrorResponse(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		if err := enc.header(k.columns); err == nil {
+			enc.flush()
+		}
+		return
+	}
+	if err != nil {
+		// The status line is already sent, so break the connection instead of
+		// ending the stream cleanly and passing a truncated export as complete.
+		log.Printf("export %s aborted: %v", mux.Vars(r)["kind"], err)
+		panic(http.ErrAbortHandler)
+	}
+}
@@ -0,0 +1,104 @@
+package export
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"async-api/internal/config"
+	"async-api/internal/domain/filmwork"
+	"async-api/internal/domain/genre"
+	"async-api/internal/domain/person"
+)
+
+// kind describes one exportable collection: where it lives, which filters it
+// accepts and how a document is turned into an NDJSON record and a CSV row.
+type kind struct {
+	index   func(config.ElasticIndicesConfig) string
+	query   func(r *http.Request) (map[string]interface{}, error)
+	columns []string
+	decode  func(source json.RawMessage) (interface{}, []string, error)
+}
+
+var kinds = map[string]kind{
+	"filmworks": {
+		index: func(i config.ElasticIndicesConfig) string { return i.Movies },
+		query: func(r *http.Request) (map[string]interface{}, error) {
+			return filmwork.SearchQuery(r.URL.Query().Get("q"))
+		},
+		columns: []string{"id", "title", "rating", "description", "release_date", "type", "genres", "actors", "writers", "directors"},
+		decode: func(source json.RawMessage) (interface{}, []string, error) {
+			var f filmworkRecord
+			if err := json.Unmarshal(source, &f); err != nil {
+				return nil, nil, err
+			}
+			return f, []string{
+				f.ID,
+				f.Title,
+				strconv.FormatFloat(float64(f.Rating), 'f', -1, 32),
+				f.Description,
+				f.ReleaseDate,
+				f.Type,
+				strings.Join(f.Genres, "|"),
+				names(f.Actors),
+				names(f.Writers),
+				names(f.Directors),
+			}, nil
+		},
+	},
+	"persons": {
+		index: func(i config.ElasticIndicesConfig) string { return i.Persons },
+		query: func(r *http.Request) (map[string]interface{}, error) {
+			if q := r.URL.Query().Get("q"); q != "" {
+				return person.SearchQuery(q), nil
+			}
+			return map[string]interface{}{"match_all": map[string]interface{}{}}, nil
+		},
+		columns: []string{"id", "name"},
+		decode: func(source json.RawMessage) (interface{}, []string, error) {
+			var p person.EsBasePerson
+			if err := json.Unmarshal(source, &p); err != nil {
+				return nil, nil, err
+			}
+			return person.BasePerson{ID: p.ID, Name: p.Name}, []string{p.ID, p.Name}, nil
+		},
+	},
+	"genres": {
+		index: func(i config.ElasticIndicesConfig) string { return i.Genres },
+		query: func(r *http.Request) (map[string]interface{}, error) {
+			return map[string]interface{}{"match_all": map[string]interface{}{}}, nil
+		},
+		columns: []string{"id", "name", "description"},
+		decode: func(source json.RawMessage) (interface{}, []string, error) {
+			var g genre.Genre
+			if err := json.Unmarshal(source, &g); err != nil {
+				return nil, nil, err
+			}
+			return g, []string{g.ID, g.Name, g.Description}, nil
+		},
+	},
+}
+
+// filmworkRecord is the exported filmwork document. Unlike filmwork.Filmwork it
+// has no audience rating fields, which export does not resolve.
+type filmworkRecord struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	Rating      float32             `json:"rating"`
+	Description string              `json:"description"`
+	ReleaseDate string              `json:"release_date"`
+	Type        string              `json:"type"`
+	Genres      []string            `json:"genres"`
+	Actors      []person.BasePerson `json:"actors"`
+	Writers     []person.BasePerson `json:"writers"`
+	Directors   []person.BasePerson `json:"directors"`
+}
+
+func names(persons []person.BasePerson) string {
+	values := make([]string, 0, len(persons))
+	for _, p := range persons {
+		values = append(values, p.Name)
+	}
+	return strings.Join(values, "|")
+}
@@ -0,0 +1,30 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"async-api/pkg/breaker"
+)
+
+// SendServiceErrorResponse maps an error returned by a service to a status
+// code: 404 for missing documents, 503 with Retry-After while a circuit
+// breaker is open, 504 when a time budget ran out and 500 otherwise.
+func SendServiceErrorResponse(w http.ResponseWriter, err error) {
+	var openErr *breaker.OpenError
+	switch {
+	case errors.As(err, &openErr):
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(openErr.RetryAfter.Seconds()))))
+		SendErrorResponse(w, err.Error(), http.StatusServiceUnavailable)
+	case errors.Is(err, context.DeadlineExceeded):
+		SendErrorResponse(w, err.Error(), http.StatusGatewayTimeout)
+	case strings.Contains(err.Error(), "not found"):
+		SendErrorResponse(w, err.Error(), http.StatusNotFound)
+	default:
+		SendErrorResponse(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ReadList returns the distinct values of a comma-separated query parameter
+// such as fields or expand, rejecting values that are not in allowed.
+func ReadList(r *http.Request, param string, allowed []string) ([]string, error) {
+	raw := r.URL.Query().Get(param)
+	if raw == "" {
+		return nil, nil
+	}
+
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" || slices.Contains(values, value) {
+			continue
+		}
+		if !slices.Contains(allowed, value) {
+			return nil, fmt.Errorf("Неверное значение %s: %s", param, value)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// Project returns the JSON object of v reduced to fields. All fields are kept
+// when fields is empty.
+func Project(v interface{}, fields []string) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	projected := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		if len(fields) == 0 || slices.Contains(fields, key) {
+			projected[key] = value
+		}
+	}
+	return projected, nil
+}
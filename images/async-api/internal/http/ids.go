@@ -0,0 +1,43 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// MaxBatchIDs caps the number of ids accepted by the batch lookup endpoints.
+const MaxBatchIDs = 100
+
+// ReadIDs returns the ids of a batch lookup, taken from the comma-separated
+// ids query parameter of a GET request or the {"ids": [...]} body of a POST
+// request. The order of the ids is preserved.
+func ReadIDs(r *http.Request) ([]string, error) {
+	var raw []string
+	if r.Method == http.MethodPost {
+		var body struct {
+			IDs []string `json:"ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return nil, fmt.Errorf("Неверный формат тела запроса")
+		}
+		raw = body.IDs
+	} else {
+		raw = strings.Split(r.URL.Query().Get("ids"), ",")
+	}
+
+	ids := make([]string, 0, len(raw))
+	for _, id := range raw {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("Не указаны ids")
+	}
+	if len(ids) > MaxBatchIDs {
+		return nil, fmt.Errorf("Можно запросить не более %d ids", MaxBatchIDs)
+	}
+	return ids, nil
+}
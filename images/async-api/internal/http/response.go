@@ -7,6 +7,9 @@ import (
 
 type errorResponse struct {
 	Message string `json:"message"`
+	// Position is the 1-based character position of a syntax error in a
+	// request parameter.
+	Position int `json:"position,omitempty"`
 }
 
 func SendSuccessResponse(w http.ResponseWriter, data interface{}, statusCode int) {
@@ -22,3 +25,14 @@ func SendErrorResponse(w http.ResponseWriter, errorMsg string, statusCode int) {
 		Message: errorMsg,
 	})
 }
+
+// SendSyntaxErrorResponse reports a malformed request parameter with 400,
+// pointing at the position of the problem.
+func SendSyntaxErrorResponse(w http.ResponseWriter, errorMsg string, position int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(errorResponse{
+		Message:  errorMsg,
+		Position: position,
+	})
+}
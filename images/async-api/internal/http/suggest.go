@@ -0,0 +1,29 @@
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ReadSuggest reads the suggest and autocorrect flags of the search
+// endpoints. Either one switches the response from a plain list to an object
+// carrying the spelling suggestion; autocorrect also reruns the search with it.
+func ReadSuggest(r *http.Request) (suggest bool, autocorrect bool, err error) {
+	for _, flag := range []struct {
+		name  string
+		value *bool
+	}{
+		{"suggest", &suggest},
+		{"autocorrect", &autocorrect},
+	} {
+		raw := r.URL.Query().Get(flag.name)
+		if raw == "" {
+			continue
+		}
+		if *flag.value, err = strconv.ParseBool(raw); err != nil {
+			return false, false, fmt.Errorf("Неверный формат %s", flag.name)
+		}
+	}
+	return suggest || autocorrect, autocorrect, nil
+}
@@ -0,0 +1,208 @@
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"async-api/internal/config"
+)
+
+// Middleware adds HTTP caching semantics to successful GET and HEAD
+// responses: the Cache-Control policy configured for the matched route and,
+// unless the handler has set validators with NotModified, a strong ETag
+// derived from the body with 304 Not Modified for a matching If-None-Match.
+// Responses with validators from the handler, and those that must not be
+// stored, are streamed; the others are buffered to hash the body. Other
+// requests pass through untouched.
+func Middleware(cfg config.HTTPCacheConfig) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+			route := mux.CurrentRoute(r)
+			if route == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			template, err := route.GetPathTemplate()
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &recorder{ResponseWriter: w, policy: cfg.Policy(template), status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			if rec.streaming {
+				return
+			}
+
+			if rec.status != http.StatusOK {
+				w.WriteHeader(rec.status)
+				w.Write(rec.body.Bytes())
+				return
+			}
+
+			w.Header().Set("Cache-Control", CacheControl(rec.policy))
+			etag := ETag(rec.body.Bytes())
+			w.Header().Set("ETag", etag)
+			if Matches(r.Header.Get("If-None-Match"), etag) {
+				w.Header().Del("Content-Type")
+				w.Header().Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			w.WriteHeader(rec.status)
+			w.Write(rec.body.Bytes())
+		})
+	}
+}
+
+// Validators are the validators of a representation known before it is
+// built, typically from the version of the document it is built from.
+type Validators struct {
+	ETag         string
+	LastModified *time.Time
+}
+
+// VersionETag returns a strong entity tag for the representation built from
+// the inputs identified by version, e.g. the _primary_term and _seq_no of the
+// Elasticsearch document.
+func VersionETag(version any) string {
+	data, _ := json.Marshal(version)
+	return ETag(data)
+}
+
+// NotModified sets v on w and reports whether the conditional headers of r
+// match it, in which case the 304 Not Modified response has been sent and the
+// handler must not write anything else. If-Modified-Since is only evaluated
+// without If-None-Match, which takes precedence.
+func NotModified(w http.ResponseWriter, r *http.Request, v Validators) bool {
+	w.Header().Set("ETag", v.ETag)
+	if v.LastModified != nil {
+		w.Header().Set("Last-Modified", v.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	notModified := false
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		notModified = Matches(ifNoneMatch, v.ETag)
+	} else if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && v.LastModified != nil {
+		notModified = !v.LastModified.Truncate(time.Second).After(since)
+	}
+	if notModified {
+		w.WriteHeader(http.StatusNotModified)
+	}
+	return notModified
+}
+
+// ETag returns a strong entity tag for body.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// Matches reports whether the If-None-Match header value matches etag using
+// the weak comparison required for GET requests.
+func Matches(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// CacheControl renders policy as a Cache-Control header value.
+func CacheControl(policy config.CachePolicy) string {
+	if policy.NoStore {
+		return "no-store"
+	}
+	directives := []string{"public"}
+	if policy.Private {
+		directives[0] = "private"
+	}
+	directives = append(directives, "max-age="+seconds(policy.MaxAge))
+	if policy.StaleWhileRevalidate > 0 {
+		directives = append(directives, "stale-while-revalidate="+seconds(policy.StaleWhileRevalidate))
+	}
+	if policy.StaleIfError > 0 {
+		directives = append(directives, "stale-if-error="+seconds(policy.StaleIfError))
+	}
+	return strings.Join(directives, ", ")
+}
+
+// SetSurrogateKeys lists the entities a response is built from so that the CDN
+// can purge it when one of them changes. Keys use the cache invalidation tag
+// format, e.g. "filmwork:<id>".
+func SetSurrogateKeys(w http.ResponseWriter, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	seen := make(map[string]struct{}, len(keys))
+	unique := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, key)
+	}
+	w.Header().Set("Surrogate-Key", strings.Join(unique, " "))
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%d", int64(d/time.Second))
+}
+
+// recorder buffers a response so that its ETag can be computed before the
+// status line is sent. Responses that need no body hash are streamed with
+// their Cache-Control header instead.
+type recorder struct {
+	http.ResponseWriter
+	policy      config.CachePolicy
+	status      int
+	wroteHeader bool
+	streaming   bool
+	body        bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.wroteHeader = true
+	r.status = status
+
+	hasValidators := r.Header().Get("ETag") != ""
+	if status == http.StatusNotModified || status == http.StatusOK && (hasValidators || r.policy.NoStore) {
+		r.Header().Set("Cache-Control", CacheControl(r.policy))
+		r.streaming = true
+		r.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	if r.streaming {
+		return r.ResponseWriter.Write(b)
+	}
+	return r.body.Write(b)
+}
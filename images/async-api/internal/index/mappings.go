@@ -0,0 +1,148 @@
+package index
+
+import "fmt"
+
+const (
+	Movies  = "movies"
+	Persons = "persons"
+	Genres  = "genres"
+)
+
+var Kinds = []string{Movies, Persons, Genres}
+
+var settings = map[string]interface{}{
+	"refresh_interval": "1s",
+	"analysis": map[string]interface{}{
+		"filter": map[string]interface{}{
+			"english_stop": map[string]interface{}{
+				"type":      "stop",
+				"stopwords": "_english_",
+			},
+			"english_stemmer": map[string]interface{}{
+				"type":     "stemmer",
+				"language": "english",
+			},
+			"english_possessive_stemmer": map[string]interface{}{
+				"type":     "stemmer",
+				"language": "possessive_english",
+			},
+			"russian_stop": map[string]interface{}{
+				"type":      "stop",
+				"stopwords": "_russian_",
+			},
+			"russian_stemmer": map[string]interface{}{
+				"type":     "stemmer",
+				"language": "russian",
+			},
+		},
+		"char_filter": map[string]interface{}{
+			"yo_to_ye": map[string]interface{}{
+				"type":     "mapping",
+				"mappings": []string{"ё => е", "Ё => Е"},
+			},
+		},
+		"analyzer": map[string]interface{}{
+			"ru_en": map[string]interface{}{
+				"tokenizer":   "standard",
+				"char_filter": []string{"yo_to_ye"},
+				"filter": []string{
+					"lowercase",
+					"english_stop",
+					"english_stemmer",
+					"english_possessive_stemmer",
+					"russian_stop",
+					"russian_stemmer",
+				},
+			},
+			// suggest keeps words as typed, without stop words or stemming,
+			// so that spelling suggestions read as real words.
+			"suggest": map[string]interface{}{
+				"tokenizer":   "standard",
+				"char_filter": []string{"yo_to_ye"},
+				"filter":      []string{"lowercase"},
+			},
+		},
+	},
+}
+
+// suggestSubfield adds the .suggest subfield read by the spelling suggesters.
+var suggestSubfield = map[string]interface{}{
+	"suggest": map[string]interface{}{"type": "text", "analyzer": "suggest"},
+}
+
+func nestedPersons() map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "nested",
+		"dynamic": "strict",
+		"properties": map[string]interface{}{
+			"id":   map[string]interface{}{"type": "keyword"},
+			"name": map[string]interface{}{"type": "text", "analyzer": "ru_en"},
+		},
+	}
+}
+
+var properties = map[string]map[string]interface{}{
+	Movies: {
+		"id":     map[string]interface{}{"type": "keyword"},
+		"rating": map[string]interface{}{"type": "float"},
+		"genres": map[string]interface{}{"type": "keyword"},
+		"title": map[string]interface{}{
+			"type":     "text",
+			"analyzer": "ru_en",
+			"fields": map[string]interface{}{
+				"raw":     map[string]interface{}{"type": "keyword"},
+				"suggest": map[string]interface{}{"type": "text", "analyzer": "suggest"},
+			},
+		},
+		"description":     map[string]interface{}{"type": "text", "analyzer": "ru_en"},
+		"release_date":    map[string]interface{}{"type": "date"},
+		"type":            map[string]interface{}{"type": "keyword"},
+		"age_rating":      map[string]interface{}{"type": "keyword"},
+		"access_type":     map[string]interface{}{"type": "keyword"},
+		"directors_names": map[string]interface{}{"type": "text", "analyzer": "ru_en", "fields": suggestSubfield},
+		"actors_names":    map[string]interface{}{"type": "text", "analyzer": "ru_en", "fields": suggestSubfield},
+		"writers_names":   map[string]interface{}{"type": "text", "analyzer": "ru_en", "fields": suggestSubfield},
+		"actors":          nestedPersons(),
+		"writers":         nestedPersons(),
+		"directors":       nestedPersons(),
+		"modified":        map[string]interface{}{"type": "date"},
+	},
+	Persons: {
+		"id": map[string]interface{}{"type": "keyword"},
+		"full_name": map[string]interface{}{
+			"type":     "text",
+			"analyzer": "ru_en",
+			"fields": map[string]interface{}{
+				"raw":     map[string]interface{}{"type": "keyword"},
+				"suggest": map[string]interface{}{"type": "text", "analyzer": "suggest"},
+			},
+		},
+		"modified": map[string]interface{}{"type": "date"},
+	},
+	Genres: {
+		"id": map[string]interface{}{"type": "keyword"},
+		"name": map[string]interface{}{
+			"type":     "text",
+			"analyzer": "ru_en",
+			"fields":   map[string]interface{}{"raw": map[string]interface{}{"type": "keyword"}},
+		},
+		"description": map[string]interface{}{"type": "text", "analyzer": "ru_en"},
+		"modified":    map[string]interface{}{"type": "date"},
+	},
+}
+
+// Definition returns the create-index body for kind. It mirrors
+// ELASTICSEARCH_SETTINGS and ELASTICSEARCH_INDICES of the admin panel.
+func Definition(kind string) (map[string]interface{}, error) {
+	props, ok := properties[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown index kind '%s'", kind)
+	}
+	return map[string]interface{}{
+		"settings": settings,
+		"mappings": map[string]interface{}{
+			"dynamic":    "strict",
+			"properties": props,
+		},
+	}, nil
+}
@@ -0,0 +1,156 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// Migrate brings the indices behind alias up to the Go-side definition of
+// kind. Missing fields are added in place; when the analysis settings differ
+// or a field of existing documents changed, such as a new subfield, the
+// documents are reindexed, since only indexing them again applies the change.
+// A nil Result means no reindex was needed, including while alias does not
+// exist.
+func (r *Reindexer) Migrate(ctx context.Context, kind string, alias string, deleteOld bool) (*Result, error) {
+	props, ok := properties[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown index kind '%s'", kind)
+	}
+	sources, _, err := r.resolve(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	var reasons []string
+	current, err := r.mappings(ctx, sources)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range slices.Sorted(maps.Keys(props)) {
+		for _, existing := range current {
+			if field, ok := existing[name]; ok && !equalDefinitions(field, props[name]) {
+				reasons = append(reasons, "field "+name)
+				break
+			}
+		}
+	}
+
+	analysis, err := r.analysis(ctx, sources)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range slices.Sorted(maps.Keys(analysis)) {
+		if !equalDefinitions(analysis[index], settings["analysis"]) {
+			reasons = append(reasons, "analysis of "+index)
+		}
+	}
+
+	// Added fields need no reindex: no document could have them before.
+	stale, err := r.UpdateMapping(ctx, kind, alias)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range stale {
+		if !slices.Contains(reasons, "field "+name) {
+			reasons = append(reasons, "field "+name)
+		}
+	}
+
+	if len(reasons) == 0 {
+		return nil, nil
+	}
+	log.Printf("%s is out of date (%v), reindexing", alias, reasons)
+	return r.Reindex(ctx, kind, alias, deleteOld)
+}
+
+// mappings returns the top-level properties of each of indices.
+func (r *Reindexer) mappings(ctx context.Context, indices []string) (map[string]map[string]interface{}, error) {
+	body, err := r.do(ctx, esapi.IndicesGetMappingRequest{Index: indices})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mappings: %w", err)
+	}
+	var response map[string]struct {
+		Mappings struct {
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"mappings"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+	result := make(map[string]map[string]interface{}, len(response))
+	for index, m := range response {
+		result[index] = m.Mappings.Properties
+	}
+	return result, nil
+}
+
+// analysis returns the analysis settings of each of indices.
+func (r *Reindexer) analysis(ctx context.Context, indices []string) (map[string]interface{}, error) {
+	body, err := r.do(ctx, esapi.IndicesGetSettingsRequest{Index: indices, Name: []string{"index.analysis*"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+	var response map[string]struct {
+		Settings struct {
+			Index struct {
+				Analysis interface{} `json:"analysis"`
+			} `json:"index"`
+		} `json:"settings"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("response parsing error: %w", err)
+	}
+	result := make(map[string]interface{}, len(response))
+	for index, s := range response {
+		result[index] = s.Settings.Index.Analysis
+	}
+	return result, nil
+}
+
+// equalDefinitions compares a definition read from Elasticsearch with a
+// Go-side one. Elasticsearch returns settings values as strings, so scalars
+// are compared by their text.
+func equalDefinitions(actual interface{}, expected interface{}) bool {
+	return reflect.DeepEqual(normalize(actual), normalize(expected))
+}
+
+func normalize(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	return stringify(decoded)
+}
+
+func stringify(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = stringify(value)
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = stringify(value)
+		}
+		return v
+	case nil:
+		return nil
+	default:
+		return fmt.Sprint(v)
+	}
+}
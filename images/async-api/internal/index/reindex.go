@@ -0,0 +1,272 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9"
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+type Reindexer struct {
+	es *elasticsearch.Client
+}
+
+func NewReindexer(es *elasticsearch.Client) *Reindexer {
+	return &Reindexer{es: es}
+}
+
+type Result struct {
+	Alias      string
+	NewIndex   string
+	OldIndices []string
+	Documents  int
+}
+
+// Reindex creates a versioned index for kind from the Go-side mapping, copies
+// the documents the alias currently points at, checks that the document
+// counts match and atomically moves the alias to the new index. A concrete
+// index that has the alias name, as created by the admin panel, is replaced by
+// the alias in the same step.
+func (r *Reindexer) Reindex(ctx context.Context, kind string, alias string, deleteOld bool) (*Result, error) {
+	definition, err := Definition(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, concrete, err := r.resolve(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Alias:      alias,
+		NewIndex:   fmt.Sprintf("%s_v%s", alias, time.Now().UTC().Format("20060102150405")),
+		OldIndices: sources,
+	}
+
+	if err := r.create(ctx, result.NewIndex, definition); err != nil {
+		return nil, err
+	}
+
+	if err := r.copyAndVerify(ctx, sources, result); err != nil {
+		if _, cleanupErr := r.do(ctx, esapi.IndicesDeleteRequest{Index: []string{result.NewIndex}}); cleanupErr != nil {
+			log.Printf("failed to delete index %s: %v", result.NewIndex, cleanupErr)
+		}
+		return nil, err
+	}
+
+	if err := r.swap(ctx, alias, result.NewIndex, sources, concrete); err != nil {
+		return nil, err
+	}
+
+	if deleteOld && !concrete && len(sources) > 0 {
+		if _, err := r.do(ctx, esapi.IndicesDeleteRequest{Index: sources}); err != nil {
+			return result, fmt.Errorf("alias swapped but failed to delete old indices: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// UpdateMapping adds the fields of the Go-side mapping of kind that the
+// indices behind alias lack, one field at a time so that a field that cannot
+// change in place does not hold back the others. The names of such fields,
+// whose new definition needs a reindex to take effect, are returned. Nothing
+// is done while alias does not exist.
+func (r *Reindexer) UpdateMapping(ctx context.Context, kind string, alias string) ([]string, error) {
+	props, ok := properties[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown index kind '%s'", kind)
+	}
+	sources, _, err := r.resolve(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	var stale []string
+	for _, name := range slices.Sorted(maps.Keys(props)) {
+		body, err := encode(map[string]interface{}{
+			"properties": map[string]interface{}{name: props[name]},
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp, err := esapi.IndicesPutMappingRequest{Index: sources, Body: body}.Do(ctx, r.es)
+		if err != nil {
+			return nil, fmt.Errorf("Elasticsearch request error: %w", err)
+		}
+		msg, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		switch {
+		case resp.StatusCode == 400:
+			stale = append(stale, name)
+		case resp.IsError():
+			return nil, fmt.Errorf("Elasticsearch error [%d]: %s", resp.StatusCode, msg)
+		}
+	}
+	return stale, nil
+}
+
+// resolve returns the indices behind alias. concrete is true when alias is
+// the name of an index rather than an alias.
+func (r *Reindexer) resolve(ctx context.Context, alias string) ([]string, bool, error) {
+	resp, err := esapi.IndicesGetAliasRequest{Name: []string{alias}}.Do(ctx, r.es)
+	if err != nil {
+		return nil, false, fmt.Errorf("Elasticsearch request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		exists, err := esapi.IndicesExistsRequest{Index: []string{alias}}.Do(ctx, r.es)
+		if err != nil {
+			return nil, false, fmt.Errorf("Elasticsearch request error: %w", err)
+		}
+		exists.Body.Close()
+		if exists.StatusCode == 200 {
+			return []string{alias}, true, nil
+		}
+		return nil, false, nil
+	}
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, false, fmt.Errorf("Elasticsearch error [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, false, fmt.Errorf("response parsing error: %w", err)
+	}
+
+	indices := make([]string, 0, len(response))
+	for name := range response {
+		indices = append(indices, name)
+	}
+	return indices, false, nil
+}
+
+func (r *Reindexer) create(ctx context.Context, name string, definition map[string]interface{}) error {
+	body, err := encode(definition)
+	if err != nil {
+		return err
+	}
+	if _, err := r.do(ctx, esapi.IndicesCreateRequest{Index: name, Body: body}); err != nil {
+		return fmt.Errorf("failed to create index %s: %w", name, err)
+	}
+	log.Printf("created index %s", name)
+	return nil
+}
+
+func (r *Reindexer) copyAndVerify(ctx context.Context, sources []string, result *Result) error {
+	if len(sources) == 0 {
+		return nil
+	}
+
+	body, err := encode(map[string]interface{}{
+		"source": map[string]interface{}{"index": sources},
+		"dest":   map[string]interface{}{"index": result.NewIndex},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := r.do(ctx, esapi.ReindexRequest{
+		Body:              body,
+		Refresh:           esapi.BoolPtr(true),
+		WaitForCompletion: esapi.BoolPtr(true),
+	}); err != nil {
+		return fmt.Errorf("failed to reindex into %s: %w", result.NewIndex, err)
+	}
+
+	sourceCount, err := r.count(ctx, sources)
+	if err != nil {
+		return err
+	}
+	newCount, err := r.count(ctx, []string{result.NewIndex})
+	if err != nil {
+		return err
+	}
+	if sourceCount != newCount {
+		return fmt.Errorf("document count mismatch: %d in %v, %d in %s", sourceCount, sources, newCount, result.NewIndex)
+	}
+
+	result.Documents = newCount
+	log.Printf("reindexed %d documents into %s", newCount, result.NewIndex)
+	return nil
+}
+
+func (r *Reindexer) count(ctx context.Context, indices []string) (int, error) {
+	var response struct {
+		Count int `json:"count"`
+	}
+	body, err := r.do(ctx, esapi.CountRequest{Index: indices})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return 0, fmt.Errorf("response parsing error: %w", err)
+	}
+	return response.Count, nil
+}
+
+func (r *Reindexer) swap(ctx context.Context, alias string, newIndex string, sources []string, concrete bool) error {
+	actions := make([]map[string]interface{}, 0, len(sources)+1)
+	for _, source := range sources {
+		if concrete {
+			actions = append(actions, map[string]interface{}{
+				"remove_index": map[string]interface{}{"index": source},
+			})
+			continue
+		}
+		actions = append(actions, map[string]interface{}{
+			"remove": map[string]interface{}{"index": source, "alias": alias},
+		})
+	}
+	actions = append(actions, map[string]interface{}{
+		"add": map[string]interface{}{"index": newIndex, "alias": alias, "is_write_index": true},
+	})
+
+	body, err := encode(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return err
+	}
+	if _, err := r.do(ctx, esapi.IndicesUpdateAliasesRequest{Body: body}); err != nil {
+		return fmt.Errorf("failed to swap alias %s: %w", alias, err)
+	}
+	log.Printf("alias %s now points at %s", alias, newIndex)
+	return nil
+}
+
+func (r *Reindexer) do(ctx context.Context, req esapi.Request) ([]byte, error) {
+	resp, err := req.Do(ctx, r.es)
+	if err != nil {
+		return nil, fmt.Errorf("Elasticsearch request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("response reading error: %w", err)
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("Elasticsearch error [%d]: %s", resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+func encode(v interface{}) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, fmt.Errorf("request coding error: %w", err)
+	}
+	return &buf, nil
+}
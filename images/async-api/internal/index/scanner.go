@@ -0,0 +1,182 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9"
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+// Scanner walks every document matching a query through a point in time, so
+// that the result is a consistent snapshot and is not limited by
+// index.max_result_window.
+type Scanner struct {
+	es        *elasticsearch.Client
+	pageSize  int
+	keepAlive time.Duration
+	timeout   time.Duration
+}
+
+// NewScanner builds a scanner reading pageSize documents per request. The
+// point in time is kept for keepAlive between requests, each of which times
+// out after timeout.
+func NewScanner(es *elasticsearch.Client, pageSize int, keepAlive time.Duration, timeout time.Duration) *Scanner {
+	return &Scanner{
+		es:        es,
+		pageSize:  pageSize,
+		keepAlive: keepAlive,
+		timeout:   timeout,
+	}
+}
+
+// Scan calls fn with the _source of every document of index matching query,
+// one page at a time, and stops at the first error fn returns. source limits
+// the returned fields; nil returns whole documents.
+func (s *Scanner) Scan(ctx context.Context, index string, query map[string]interface{}, source []string, fn func(page []json.RawMessage) error) error {
+	pitID, err := s.openPIT(ctx, index)
+	if err != nil {
+		return err
+	}
+	defer func() { s.closePIT(pitID) }()
+
+	var searchAfter []interface{}
+	for {
+		body := map[string]interface{}{
+			"query": query,
+			"size":  s.pageSize,
+			"pit": map[string]interface{}{
+				"id":         pitID,
+				"keep_alive": keepAlive(s.keepAlive),
+			},
+			"sort": []map[string]interface{}{
+				{"_shard_doc": "asc"},
+			},
+			"track_total_hits": false,
+		}
+		if source != nil {
+			body["_source"] = source
+		}
+		if searchAfter != nil {
+			body["search_after"] = searchAfter
+		}
+
+		var page struct {
+			PitID string `json:"pit_id"`
+			Hits  struct {
+				Hits []struct {
+					Source json.RawMessage `json:"_source"`
+					Sort   []interface{}   `json:"sort"`
+				} `json:"hits"`
+			} `json:"hits"`
+		}
+		if err := s.search(ctx, body, &page); err != nil {
+			return err
+		}
+		if page.PitID != "" {
+			pitID = page.PitID
+		}
+
+		hits := page.Hits.Hits
+		if len(hits) == 0 {
+			return nil
+		}
+		sources := make([]json.RawMessage, 0, len(hits))
+		for _, hit := range hits {
+			sources = append(sources, hit.Source)
+		}
+		if err := fn(sources); err != nil {
+			return err
+		}
+		if len(hits) < s.pageSize {
+			return nil
+		}
+		searchAfter = hits[len(hits)-1].Sort
+	}
+}
+
+func (s *Scanner) openPIT(ctx context.Context, index string) (string, error) {
+	req := esapi.OpenPointInTimeRequest{
+		Index:     []string{index},
+		KeepAlive: keepAlive(s.keepAlive),
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, s.es)
+	if err != nil {
+		return "", fmt.Errorf("Elasticsearch request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("error Elasticsearch [%d]: %s", resp.StatusCode, body)
+	}
+
+	var response struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", fmt.Errorf("response parsing error: %w", err)
+	}
+	return response.ID, nil
+}
+
+// closePIT releases the point in time. It runs detached from the request
+// context so that the search context is freed even when the client went away.
+func (s *Scanner) closePIT(pitID string) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]interface{}{"id": pitID}); err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	resp, err := esapi.ClosePointInTimeRequest{Body: &buf}.Do(ctx, s.es)
+	if err != nil {
+		log.Printf("failed to close point in time: %v", err)
+		return
+	}
+	resp.Body.Close()
+}
+
+func (s *Scanner) search(ctx context.Context, body map[string]interface{}, out interface{}) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return fmt.Errorf("request coding error: %w", err)
+	}
+
+	// The index comes from the point in time and must not be repeated.
+	req := esapi.SearchRequest{Body: &buf}
+
+	reqCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	resp, err := req.Do(reqCtx, s.es)
+	if err != nil {
+		return fmt.Errorf("Elasticsearch search error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error Elasticsearch [%d]: %s", resp.StatusCode, body)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("response parsing error: %w", err)
+	}
+	return nil
+}
+
+func keepAlive(d time.Duration) string {
+	return fmt.Sprintf("%ds", int64(d/time.Second))
+}
@@ -0,0 +1,61 @@
+package index
+
+// PhraseSuggest returns the suggest section of a search body proposing a
+// spelling correction of text for each of fields. Corrections are read from
+// the .suggest subfield and only kept when they match a document on the field
+// itself, so that a suggestion never leads to an empty result.
+func PhraseSuggest(text string, fields []string) map[string]interface{} {
+	suggest := map[string]interface{}{"text": text}
+	for _, field := range fields {
+		suggest[field] = map[string]interface{}{
+			"phrase": map[string]interface{}{
+				"field":      field + ".suggest",
+				"size":       1,
+				"max_errors": 2,
+				"direct_generator": []map[string]interface{}{{
+					"field":           field + ".suggest",
+					"suggest_mode":    "always",
+					"min_word_length": 3,
+				}},
+				"collate": map[string]interface{}{
+					"query": map[string]interface{}{
+						"source": map[string]interface{}{
+							"match": map[string]interface{}{
+								field: map[string]interface{}{
+									"query":    "{{suggestion}}",
+									"operator": "and",
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	return suggest
+}
+
+// Suggestions is the suggest section of a search response.
+type Suggestions map[string][]struct {
+	Options []struct {
+		Text  string  `json:"text"`
+		Score float64 `json:"score"`
+	} `json:"options"`
+}
+
+// Best returns the highest scored correction across all suggesters, the
+// alphabetically first on a tie, or an empty string when there is none.
+func (s Suggestions) Best() string {
+	var best string
+	var bestScore float64
+	for _, entries := range s {
+		for _, entry := range entries {
+			for _, option := range entry.Options {
+				if best == "" || option.Score > bestScore || option.Score == bestScore && option.Text < best {
+					best, bestScore = option.Text, option.Score
+				}
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,28 @@
+package index
+
+import "time"
+
+// Version identifies the revision of a document. PrimaryTerm and SeqNo change
+// with every write; Modified is the time the writer stamped the document with,
+// missing on documents written before the modified field existed.
+type Version struct {
+	PrimaryTerm int64      `json:"primary_term"`
+	SeqNo       int64      `json:"seq_no"`
+	Modified    *time.Time `json:"modified,omitempty"`
+}
+
+// VersionFields are the _source fields a get request for a Version needs.
+var VersionFields = []string{"modified"}
+
+// VersionResponse is the part of a get response a Version is read from.
+type VersionResponse struct {
+	PrimaryTerm int64 `json:"_primary_term"`
+	SeqNo       int64 `json:"_seq_no"`
+	Source      struct {
+		Modified *time.Time `json:"modified"`
+	} `json:"_source"`
+}
+
+func (r VersionResponse) Version() *Version {
+	return &Version{PrimaryTerm: r.PrimaryTerm, SeqNo: r.SeqNo, Modified: r.Source.Modified}
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"async-api/pkg/breaker"
+)
+
+var CacheStaleResponses = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "async_api_cache_stale_responses_total",
+	Help: "Responses served from expired cache entries because the backend was unavailable.",
+})
+
+var CacheInvalidatedKeys = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "async_api_cache_invalidated_keys_total",
+	Help: "Cache keys evicted because an entity they reference has changed.",
+})
+
+// CacheMetrics counts the events of a cache.Cache.
+type CacheMetrics struct{}
+
+func (CacheMetrics) StaleResponse() {
+	CacheStaleResponses.Inc()
+}
+
+func (CacheMetrics) InvalidatedKeys(n int) {
+	CacheInvalidatedKeys.Add(float64(n))
+}
+
+func RegisterBreaker(b *breaker.Breaker) {
+	labels := prometheus.Labels{"name": b.Name()}
+	prometheus.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name:        "async_api_circuit_breaker_state",
+			Help:        "Circuit breaker state: 0 closed, 1 half-open, 2 open.",
+			ConstLabels: labels,
+		}, b.StateValue),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name:        "async_api_circuit_breaker_rejected_total",
+			Help:        "Calls rejected while the circuit breaker was open.",
+			ConstLabels: labels,
+		}, func() float64 { return float64(b.Rejected()) }),
+	)
+}
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,244 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"async-api/internal/domain/filmwork"
+	"async-api/internal/domain/genre"
+	"async-api/internal/domain/person"
+	"async-api/pkg/breaker"
+	"async-api/pkg/catalogpb"
+)
+
+type CatalogServer struct {
+	catalogpb.UnimplementedCatalogServiceServer
+	filmworkService filmwork.FilmworkService
+	personService   person.PersonService
+	genreService    genre.GenreService
+}
+
+func NewCatalogServer(
+	filmworkService filmwork.FilmworkService,
+	personService person.PersonService,
+	genreService genre.GenreService,
+) *CatalogServer {
+	return &CatalogServer{
+		filmworkService: filmworkService,
+		personService:   personService,
+		genreService:    genreService,
+	}
+}
+
+// NewServer returns the gRPC server and its health service. The health
+// status stays NOT_SERVING until SetServing reports the server ready.
+func NewServer(catalog *CatalogServer) (*grpc.Server, *health.Server) {
+	server := grpc.NewServer()
+
+	catalogpb.RegisterCatalogServiceServer(server, catalog)
+
+	healthServer := health.NewServer()
+	SetServing(healthServer, false)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+
+	reflection.Register(server)
+
+	return server, healthServer
+}
+
+// SetServing sets the health status of the server as a whole and of the
+// catalog service.
+func SetServing(healthServer *health.Server, serving bool) {
+	status := grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	healthServer.SetServingStatus("", status)
+	healthServer.SetServingStatus(catalogpb.CatalogService_ServiceDesc.ServiceName, status)
+}
+
+func (s *CatalogServer) GetFilmwork(ctx context.Context, req *catalogpb.GetFilmworkRequest) (*catalogpb.Filmwork, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	f, err := s.filmworkService.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toFilmwork(f), nil
+}
+
+func (s *CatalogServer) GetFilmworks(ctx context.Context, req *catalogpb.GetFilmworksRequest) (*catalogpb.GetFilmworksResponse, error) {
+	if len(req.GetIds()) == 0 {
+		return &catalogpb.GetFilmworksResponse{}, nil
+	}
+	batch, err := s.filmworkService.GetByIDs(ctx, req.GetIds())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &catalogpb.GetFilmworksResponse{
+		Filmworks:  make([]*catalogpb.Filmwork, 0, len(batch.Items)),
+		MissingIds: batch.Missing,
+	}
+	for _, f := range batch.Items {
+		resp.Filmworks = append(resp.Filmworks, toFilmwork(f))
+	}
+	return resp, nil
+}
+
+func (s *CatalogServer) ListFilmworks(ctx context.Context, req *catalogpb.ListFilmworksRequest) (*catalogpb.ListFilmworksResponse, error) {
+	pageNumber := int(req.GetPageNumber())
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 100
+	}
+	filmworks, err := s.filmworkService.GetAll(ctx, pageNumber, pageSize, "", filmwork.PersonFilter{})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &catalogpb.ListFilmworksResponse{Filmworks: toBaseFilmworks(filmworks)}, nil
+}
+
+func (s *CatalogServer) SearchFilmworks(ctx context.Context, req *catalogpb.SearchFilmworksRequest) (*catalogpb.SearchFilmworksResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 || limit > 1000 {
+		limit = 1000
+	}
+	result, err := s.filmworkService.SearchWithSuggestion(ctx, req.GetQuery(), limit, req.GetAutocorrect())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &catalogpb.SearchFilmworksResponse{
+		Filmworks:  toBaseFilmworks(result.Items),
+		Suggestion: result.Suggestion,
+		Corrected:  result.Corrected,
+	}, nil
+}
+
+func (s *CatalogServer) GetPerson(ctx context.Context, req *catalogpb.GetPersonRequest) (*catalogpb.Person, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	p, err := s.personService.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &catalogpb.Person{
+		Id:          p.ID,
+		Name:        p.Name,
+		Roles:       p.Roles,
+		FilmworkIds: p.FilmworkIDs,
+	}, nil
+}
+
+func (s *CatalogServer) GetPersonFilmworks(ctx context.Context, req *catalogpb.GetPersonFilmworksRequest) (*catalogpb.GetPersonFilmworksResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	filmworks, err := s.personService.GetPersonFilmworks(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &catalogpb.GetPersonFilmworksResponse{
+		Filmworks: make([]*catalogpb.BaseFilmwork, 0, len(filmworks)),
+	}
+	for _, f := range filmworks {
+		resp.Filmworks = append(resp.Filmworks, &catalogpb.BaseFilmwork{
+			Id:     f.ID,
+			Title:  f.Title,
+			Rating: f.Rating,
+		})
+	}
+	return resp, nil
+}
+
+func (s *CatalogServer) GenreList(ctx context.Context, req *catalogpb.GenreListRequest) (*catalogpb.GenreListResponse, error) {
+	genres, err := s.genreService.GetAll(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &catalogpb.GenreListResponse{
+		Genres: make([]*catalogpb.Genre, 0, len(genres)),
+	}
+	for _, g := range genres {
+		resp.Genres = append(resp.Genres, &catalogpb.Genre{
+			Id:          g.ID,
+			Name:        g.Name,
+			Description: g.Description,
+		})
+	}
+	return resp, nil
+}
+
+func toFilmwork(f *filmwork.Filmwork) *catalogpb.Filmwork {
+	return &catalogpb.Filmwork{
+		Id:          f.ID,
+		Title:       f.Title,
+		Rating:      f.Rating,
+		Description: f.Description,
+		ReleaseDate: f.ReleaseDate.String(),
+		Type:        f.Type,
+		Genres:      f.Genres,
+		Actors:      toBasePersons(f.Actors),
+		Writers:     toBasePersons(f.Writers),
+		Directors:   toBasePersons(f.Directors),
+		UserRating:  f.UserRating,
+		VotesCount:  int32(f.VotesCount),
+	}
+}
+
+func toBaseFilmworks(filmworks []*filmwork.BaseFilmwork) []*catalogpb.BaseFilmwork {
+	result := make([]*catalogpb.BaseFilmwork, 0, len(filmworks))
+	for _, f := range filmworks {
+		result = append(result, &catalogpb.BaseFilmwork{
+			Id:         f.ID,
+			Title:      f.Title,
+			Rating:     f.Rating,
+			UserRating: f.UserRating,
+			VotesCount: int32(f.VotesCount),
+		})
+	}
+	return result
+}
+
+func toBasePersons(persons []person.BasePerson) []*catalogpb.BasePerson {
+	result := make([]*catalogpb.BasePerson, 0, len(persons))
+	for _, p := range persons {
+		result = append(result, &catalogpb.BasePerson{
+			Id:   p.ID,
+			Name: p.Name,
+		})
+	}
+	return result
+}
+
+func isNotFound(err error) bool {
+	return strings.Contains(err.Error(), "not found")
+}
+
+func toStatus(err error) error {
+	var queryErr *filmwork.QueryError
+	switch {
+	case errors.As(err, &queryErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, breaker.ErrOpen):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case isNotFound(err):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
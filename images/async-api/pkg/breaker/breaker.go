@@ -0,0 +1,127 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sony/gobreaker/v2"
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+type OpenError struct {
+	Name       string
+	RetryAfter time.Duration
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("%s unavailable: %s", e.Name, ErrOpen)
+}
+
+func (e *OpenError) Unwrap() error {
+	return ErrOpen
+}
+
+type Breaker struct {
+	cb          *gobreaker.TwoStepCircuitBreaker[struct{}]
+	openTimeout time.Duration
+	rejected    atomic.Uint64
+
+	mu       sync.RWMutex
+	openedAt time.Time
+}
+
+// Config tunes a Breaker. Failures are counted over the rolling Window; an
+// open breaker lets a trial call through after OpenTimeout.
+type Config struct {
+	Window       time.Duration
+	MinRequests  int
+	FailureRatio float64
+	OpenTimeout  time.Duration
+}
+
+// New returns a breaker that opens once at least MinRequests were made within
+// the rolling window and the share of failures reached FailureRatio.
+func New(name string, cfg Config) *Breaker {
+	b := &Breaker{openTimeout: cfg.OpenTimeout}
+	b.cb = gobreaker.NewTwoStepCircuitBreaker[struct{}](gobreaker.Settings{
+		Name:         name,
+		MaxRequests:  1,
+		Interval:     cfg.Window,
+		BucketPeriod: cfg.Window / 10,
+		Timeout:      cfg.OpenTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			if counts.Requests < uint32(cfg.MinRequests) {
+				return false
+			}
+			return float64(counts.TotalFailures)/float64(counts.Requests) >= cfg.FailureRatio
+		},
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			if to == gobreaker.StateOpen {
+				b.mu.Lock()
+				b.openedAt = time.Now()
+				b.mu.Unlock()
+			}
+		},
+		IsExcluded: func(err error) bool {
+			return errors.Is(err, context.Canceled)
+		},
+	})
+	return b
+}
+
+// Allow reports whether a call may proceed. The returned done func must be
+// called with the outcome of the call.
+func (b *Breaker) Allow() (func(err error), error) {
+	done, err := b.cb.Allow()
+	if err != nil {
+		b.rejected.Add(1)
+		return nil, &OpenError{Name: b.cb.Name(), RetryAfter: b.RetryAfter()}
+	}
+	return done, nil
+}
+
+func (b *Breaker) Name() string {
+	return b.cb.Name()
+}
+
+func (b *Breaker) State() string {
+	return b.cb.State().String()
+}
+
+func (b *Breaker) IsOpen() bool {
+	return b.cb.State() == gobreaker.StateOpen
+}
+
+// StateValue returns 0 when closed, 1 when half-open and 2 when open.
+func (b *Breaker) StateValue() float64 {
+	switch b.cb.State() {
+	case gobreaker.StateHalfOpen:
+		return 1
+	case gobreaker.StateOpen:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func (b *Breaker) Rejected() uint64 {
+	return b.rejected.Load()
+}
+
+func (b *Breaker) RetryAfter() time.Duration {
+	if !b.IsOpen() {
+		return time.Second
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	remaining := b.openTimeout - time.Since(b.openedAt)
+	if remaining < time.Second {
+		return time.Second
+	}
+	return remaining
+}
@@ -0,0 +1,223 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"async-api/pkg/breaker"
+)
+
+type Cache struct {
+	client   *redis.Client
+	ttl      time.Duration
+	staleTTL time.Duration
+	metrics  Metrics
+}
+
+// Metrics is told about stale responses and evicted keys.
+type Metrics interface {
+	StaleResponse()
+	InvalidatedKeys(n int)
+}
+
+type entry struct {
+	StoredAt time.Time       `json:"stored_at"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// New returns a cache whose entries are fresh for ttl and are kept for another
+// staleTTL to be served while the backing store is unavailable. metrics may be
+// nil.
+func New(client *redis.Client, ttl time.Duration, staleTTL time.Duration, metrics Metrics) *Cache {
+	return &Cache{
+		client:   client,
+		ttl:      ttl,
+		staleTTL: staleTTL,
+		metrics:  metrics,
+	}
+}
+
+func (c *Cache) staleResponse() {
+	if c.metrics != nil {
+		c.metrics.StaleResponse()
+	}
+}
+
+func (c *Cache) get(ctx context.Context, key string) (*entry, error) {
+	data, err := c.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (c *Cache) set(ctx context.Context, key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	e, err := json.Marshal(entry{StoredAt: time.Now(), Value: data})
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, key, e, c.ttl+c.staleTTL).Err()
+}
+
+// Fetch returns the cached value for key while it is fresh and otherwise calls
+// load and caches its result. When load fails because a circuit breaker is
+// open, an expired entry is returned instead of the error if one is kept.
+func Fetch[T any](ctx context.Context, c *Cache, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	return FetchTagged(ctx, c, key, nil, load)
+}
+
+// FetchTagged works like Fetch and additionally records key under the tags
+// reported for the value, so that Invalidate can evict it once one of the
+// entities it references changes.
+func FetchTagged[T any](ctx context.Context, c *Cache, key string, tags func(T) []string, load func(ctx context.Context) (T, error)) (T, error) {
+	if c == nil {
+		return load(ctx)
+	}
+
+	cached, err := c.get(ctx, key)
+	if err != nil {
+		log.Printf("cache get %s: %v", key, err)
+	}
+	if cached != nil && time.Since(cached.StoredAt) < c.ttl {
+		var value T
+		if err := json.Unmarshal(cached.Value, &value); err == nil {
+			return value, nil
+		}
+	}
+
+	value, err := load(ctx)
+	if err != nil {
+		if cached != nil && errors.Is(err, breaker.ErrOpen) {
+			var stale T
+			if jsonErr := json.Unmarshal(cached.Value, &stale); jsonErr == nil {
+				c.staleResponse()
+				return stale, nil
+			}
+		}
+		return value, err
+	}
+
+	if err := c.set(ctx, key, value); err != nil {
+		log.Printf("cache set %s: %v", key, err)
+	}
+	track(ctx, c, key, tags, value)
+	return value, nil
+}
+
+func track[T any](ctx context.Context, c *Cache, key string, tags func(T) []string, value T) {
+	if tags == nil {
+		return
+	}
+	if err := c.tag(ctx, key, tags(value)); err != nil {
+		log.Printf("cache tag %s: %v", key, err)
+	}
+}
+
+// FetchMany is the batch counterpart of FetchTagged. It reads the entries of
+// all ids in one round trip and calls load only for the ids that are not
+// cached or have expired. Ids that load does not return are treated as
+// missing and are not cached.
+func FetchMany[T any](ctx context.Context, c *Cache, ids []string, key func(id string) string, tags func(T) []string, load func(ctx context.Context, ids []string) (map[string]T, error)) (map[string]T, error) {
+	if c == nil || len(ids) == 0 {
+		return load(ctx, ids)
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = key(id)
+	}
+	cached, err := c.getMany(ctx, keys)
+	if err != nil {
+		log.Printf("cache mget: %v", err)
+	}
+
+	values := make(map[string]T, len(ids))
+	stale := make(map[string]*entry)
+	var toLoad []string
+	queued := make(map[string]struct{})
+	for i, id := range ids {
+		if _, ok := values[id]; ok {
+			continue
+		}
+		if _, ok := queued[id]; ok {
+			continue
+		}
+		queued[id] = struct{}{}
+		e := cached[keys[i]]
+		if e != nil && time.Since(e.StoredAt) < c.ttl {
+			var value T
+			if err := json.Unmarshal(e.Value, &value); err == nil {
+				values[id] = value
+				continue
+			}
+		}
+		if e != nil {
+			stale[id] = e
+		}
+		toLoad = append(toLoad, id)
+	}
+	if len(toLoad) == 0 {
+		return values, nil
+	}
+
+	loaded, err := load(ctx, toLoad)
+	if err != nil {
+		if !errors.Is(err, breaker.ErrOpen) || len(stale) < len(toLoad) {
+			return nil, err
+		}
+		for _, id := range toLoad {
+			var value T
+			if jsonErr := json.Unmarshal(stale[id].Value, &value); jsonErr != nil {
+				return nil, err
+			}
+			values[id] = value
+		}
+		c.staleResponse()
+		return values, nil
+	}
+
+	for id, value := range loaded {
+		values[id] = value
+		if err := c.set(ctx, key(id), value); err != nil {
+			log.Printf("cache set %s: %v", key(id), err)
+		}
+		track(ctx, c, key(id), tags, value)
+	}
+	return values, nil
+}
+
+func (c *Cache) getMany(ctx context.Context, keys []string) (map[string]*entry, error) {
+	data, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]*entry, len(keys))
+	for i, raw := range data {
+		s, ok := raw.(string)
+		if !ok {
+			continue
+		}
+		var e entry
+		if err := json.Unmarshal([]byte(s), &e); err != nil {
+			continue
+		}
+		entries[keys[i]] = &e
+	}
+	return entries, nil
+}
@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	EntityFilmwork = "filmwork"
+	EntityPerson   = "person"
+	EntityGenre    = "genre"
+)
+
+// Event announces that a catalogue entity has been created, changed or
+// deleted. Publishers such as the admin panel or the ETL post it as JSON, e.g.
+// {"entity": "person", "id": "..."}, to the invalidation channel.
+type Event struct {
+	Entity string `json:"entity"`
+	ID     string `json:"id"`
+}
+
+// Publish posts events to channel in a single round trip.
+func Publish(ctx context.Context, client *redis.Client, channel string, events ...Event) error {
+	pipe := client.Pipeline()
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode invalidation event: %w", err)
+		}
+		pipe.Publish(ctx, channel, payload)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to publish invalidation events: %w", err)
+	}
+	return nil
+}
+
+// Invalidate evicts every key recorded under one of tags. Only the keys read
+// are removed from the tag sets, so keys recorded meanwhile stay tracked.
+func (c *Cache) Invalidate(ctx context.Context, tags ...string) error {
+	pipe := c.client.Pipeline()
+	members := make([]*redis.StringSliceCmd, len(tags))
+	for i, tag := range tags {
+		members[i] = pipe.SMembers(ctx, tagKey(tag))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	seen := map[string]struct{}{}
+	var keys []string
+	pipe = c.client.Pipeline()
+	for i, tag := range tags {
+		tagged := members[i].Val()
+		if len(tagged) == 0 {
+			continue
+		}
+		remove := make([]interface{}, 0, len(tagged))
+		for _, key := range tagged {
+			remove = append(remove, key)
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+		pipe.SRem(ctx, tagKey(tag), remove...)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	pipe.Del(ctx, keys...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	if c.metrics != nil {
+		c.metrics.InvalidatedKeys(len(keys))
+	}
+	return nil
+}
+
+// Subscribe evicts the keys referencing the entities announced on channel
+// until ctx is cancelled. Every instance subscribes on its own; the first to
+// handle an event evicts the keys, for the tag sets are shared in Redis.
+func (c *Cache) Subscribe(ctx context.Context, channel string) {
+	sub := c.client.Subscribe(ctx, channel)
+	defer sub.Close()
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			var event Event
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil || event.Entity == "" || event.ID == "" {
+				log.Printf("skipping malformed invalidation event %q", msg.Payload)
+				continue
+			}
+			if err := c.Invalidate(ctx, Tag(event.Entity, event.ID)); err != nil {
+				log.Printf("cache invalidate %s %s: %v", event.Entity, event.ID, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+)
+
+// Tag returns the invalidation tag of the entity of the given kind and id.
+func Tag(entity string, id string) string {
+	return entity + ":" + id
+}
+
+// Tags returns the invalidation tags of all ids of one entity kind.
+func Tags(entity string, ids ...string) []string {
+	tags := make([]string, 0, len(ids))
+	for _, id := range ids {
+		tags = append(tags, Tag(entity, id))
+	}
+	return tags
+}
+
+// tagKey is the Redis set of the cache keys recorded under tag.
+func tagKey(tag string) string {
+	return "tags:" + tag
+}
+
+// tag records key under tags. Tag sets are kept in Redis so that every
+// instance can evict the keys written by any other; a set lives as long as
+// the longest-lived key recorded in it.
+func (c *Cache) tag(ctx context.Context, key string, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	ttl := c.ttl + c.staleTTL
+	pipe := c.client.Pipeline()
+	for _, tag := range tags {
+		pipe.SAdd(ctx, tagKey(tag), key)
+		pipe.ExpireNX(ctx, tagKey(tag), ttl)
+		pipe.ExpireGT(ctx, tagKey(tag), ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
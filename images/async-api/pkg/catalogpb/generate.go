@@ -0,0 +1,3 @@
+package catalogpb
+
+//go:generate protoc -I ../../api/proto --go_out=. --go_opt=module=async-api/pkg/catalogpb --go-grpc_out=. --go-grpc_opt=module=async-api/pkg/catalogpb catalog/v1/catalog.proto
@@ -2,16 +2,41 @@ package database
 
 import (
 	"async-api/internal/config"
+	"async-api/pkg/breaker"
+	"context"
+	"errors"
 	"fmt"
-	"github.com/elastic/go-elasticsearch/v9"
+	"io"
 	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/elastic/elastic-transport-go/v8/elastictransport"
+	"github.com/elastic/go-elasticsearch/v9"
 )
 
-func SetupElasticClient(cfg config.Config) (*elasticsearch.Client, error) {
+func SetupElasticClient(cfg config.Config, b *breaker.Breaker) (*elasticsearch.Client, error) {
 	esCfg := elasticsearch.Config{
-		Addresses: []string{"http://" + cfg.Elastic.Host + ":" + cfg.Elastic.Port},
-		Username:  cfg.Elastic.User,
-		Password:  cfg.Elastic.Password,
+		Addresses:              cfg.Elastic.URLs(),
+		Username:               cfg.Elastic.User,
+		Password:               cfg.Elastic.Password,
+		APIKey:                 cfg.Elastic.APIKey,
+		ServiceToken:           cfg.Elastic.ServiceToken,
+		CertificateFingerprint: cfg.Elastic.CertificateFingerprint,
+		DiscoverNodesInterval:  cfg.Elastic.DiscoverNodesInterval,
+		RetryOnStatus:          cfg.Elastic.RetryOnStatus,
+		MaxRetries:             cfg.Elastic.MaxRetries,
+		DisableRetry:           cfg.Elastic.MaxRetries == 0,
+		RetryBackoff:           exponentialBackoff(cfg.Elastic.RetryBackoff),
+	}
+
+	if cfg.Elastic.CACert != "" {
+		caCert, err := os.ReadFile(cfg.Elastic.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading CA certificate: %s", err)
+		}
+		esCfg.CACert = caCert
 	}
 
 	es, err := elasticsearch.NewClient(esCfg)
@@ -19,7 +44,103 @@ func SetupElasticClient(cfg config.Config) (*elasticsearch.Client, error) {
 		return nil, fmt.Errorf("Error creating client: %s", err)
 	}
 
-	log.Println(es.Info())
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Elastic.StartupTimeout)
+	defer cancel()
+
+	if err := ping(ctx, es); err != nil {
+		return nil, err
+	}
+
+	if b != nil {
+		es.Transport = &breakerTransport{next: es.Transport, breaker: b}
+	}
+
+	// Started here rather than by the client, whose discovery would read the
+	// transport while it is being wrapped.
+	if cfg.Elastic.DiscoverNodesOnStart {
+		go func() {
+			if err := es.DiscoverNodes(); err != nil {
+				log.Printf("Elasticsearch node discovery failed: %v", err)
+			}
+		}()
+	}
 
 	return es, nil
 }
+
+// breakerTransport reports the outcome of every request, after the client's
+// own retries, to the circuit breaker and rejects requests while it is open.
+type breakerTransport struct {
+	next    elastictransport.Interface
+	breaker *breaker.Breaker
+}
+
+// DiscoverNodes lets the client reload the connections of the wrapped
+// transport.
+func (t *breakerTransport) DiscoverNodes() error {
+	if dt, ok := t.next.(elastictransport.Discoverable); ok {
+		return dt.DiscoverNodes()
+	}
+	return errors.New("transport is not discoverable")
+}
+
+func (t *breakerTransport) Perform(req *http.Request) (*http.Response, error) {
+	done, err := t.breaker.Allow()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.Perform(req)
+	switch {
+	case err != nil:
+		done(err)
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		done(fmt.Errorf("Elasticsearch error [%d]", resp.StatusCode))
+	default:
+		done(nil)
+	}
+	return resp, err
+}
+
+// ping retries the cluster info request until it succeeds or ctx expires so
+// that startup fails fast on an unreachable or misconfigured cluster.
+func ping(ctx context.Context, es *elasticsearch.Client) error {
+	var lastErr error
+	for {
+		resp, err := es.Info(es.Info.WithContext(ctx))
+		if err == nil {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if !resp.IsError() {
+				log.Printf("Connected to Elasticsearch: %s", body)
+				return nil
+			}
+			lastErr = fmt.Errorf("Elasticsearch error [%d]: %s", resp.StatusCode, body)
+			if resp.StatusCode == 401 || resp.StatusCode == 403 {
+				return fmt.Errorf("Elasticsearch rejected credentials: %w", lastErr)
+			}
+		} else {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Elasticsearch is unreachable: %w", lastErr)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func exponentialBackoff(base time.Duration) func(attempt int) time.Duration {
+	const maxBackoff = 10 * time.Second
+	return func(attempt int) time.Duration {
+		if base <= 0 || attempt < 1 {
+			return 0
+		}
+		backoff := base << (attempt - 1)
+		if backoff <= 0 || backoff > maxBackoff {
+			return maxBackoff
+		}
+		return backoff
+	}
+}
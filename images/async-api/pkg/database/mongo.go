@@ -0,0 +1,50 @@
+package database
+
+import (
+	"async-api/internal/config"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func SetupMongoClient(cfg config.Config) (*mongo.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.Mongo.URI()))
+	if err != nil {
+		return nil, fmt.Errorf("Error creating Mongo client: %s", err)
+	}
+
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(context.Background())
+		return nil, fmt.Errorf("Mongo is unreachable: %w", err)
+	}
+
+	return client, nil
+}
+
+// UUIDToBinary encodes a UUID the way the ugc service stores it:
+// Binary(uuid.bytes) with the generic subtype.
+func UUIDToBinary(id string) (primitive.Binary, error) {
+	data, err := hex.DecodeString(strings.ReplaceAll(id, "-", ""))
+	if err != nil || len(data) != 16 {
+		return primitive.Binary{}, fmt.Errorf("invalid UUID '%s'", id)
+	}
+	return primitive.Binary{Subtype: 0x00, Data: data}, nil
+}
+
+// BinaryToUUID is the inverse of UUIDToBinary.
+func BinaryToUUID(b primitive.Binary) string {
+	h := hex.EncodeToString(b.Data)
+	if len(h) != 32 {
+		return h
+	}
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"async-api/internal/config"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func SetupPostgresPool(cfg config.Config) (*pgxpool.Pool, error) {
+	if err := cfg.Postgres.Validate(); err != nil {
+		return nil, err
+	}
+
+	pool, err := pgxpool.New(context.Background(), cfg.Postgres.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("Error creating Postgres pool: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("Postgres is unreachable: %w", err)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"async-api/internal/config"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func SetupRedisClient(cfg config.Config) (*redis.Client, error) {
+	db, err := strconv.Atoi(cfg.Redis.DB)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REDIS_DB: %s", err)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr: cfg.Redis.Host + ":" + cfg.Redis.Port,
+		DB:   db,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("Redis is unreachable: %w", err)
+	}
+
+	return client, nil
+}
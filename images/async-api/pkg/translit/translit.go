@@ -0,0 +1,218 @@
+// Package translit converts search queries between the Cyrillic and Latin
+// spellings of Russian names, so that "Tarkovsky" finds "Тарковский" and the
+// other way round.
+package translit
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Scheme maps lowercase Cyrillic letters to Latin.
+type Scheme struct {
+	letters map[rune]string
+	// iotated is used instead of letters for е at the start of a word and
+	// after a vowel, ъ or ь.
+	iotated map[rune]string
+	// endings replace word endings before the letters are mapped.
+	endings map[string]string
+}
+
+// GOST is GOST 7.79-2000 system B without the apostrophes, which the search
+// analyzer drops anyway.
+var GOST = Scheme{
+	letters: map[rune]string{
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+		'ж': "zh", 'з': "z", 'и': "i", 'й': "j", 'к': "k", 'л': "l", 'м': "m",
+		'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+		'ф': "f", 'х': "x", 'ц': "cz", 'ч': "ch", 'ш': "sh", 'щ': "shh", 'ъ': "",
+		'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	},
+}
+
+// BGN is the BGN/PCGN romanization, which most English sources use.
+var BGN = Scheme{
+	letters: map[rune]string{
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+		'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+		'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+		'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "",
+		'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	},
+	iotated: map[rune]string{'е': "ye", 'ё': "yo"},
+}
+
+// CommonBGN is BGN as names are usually spelled in the press: "Tarkovsky"
+// rather than "Tarkovskiy", "Evgeny" rather than "Yevgeniy".
+var CommonBGN = Scheme{
+	letters: BGN.letters,
+	endings: map[string]string{"ий": "y", "ый": "y"},
+}
+
+// fromLatin maps Latin letter groups to Cyrillic, longest first.
+var fromLatin = []struct{ latin, cyrillic string }{
+	{"shch", "щ"}, {"shh", "щ"},
+	{"zh", "ж"}, {"kh", "х"}, {"ts", "ц"}, {"cz", "ц"}, {"ch", "ч"}, {"sh", "ш"},
+	{"yu", "ю"}, {"ju", "ю"}, {"ya", "я"}, {"ja", "я"}, {"yo", "е"}, {"ye", "е"},
+	{"ph", "ф"}, {"th", "т"}, {"ck", "к"}, {"ce", "се"}, {"ci", "си"},
+	{"a", "а"}, {"b", "б"}, {"c", "к"}, {"d", "д"}, {"e", "е"}, {"f", "ф"},
+	{"g", "г"}, {"h", "х"}, {"i", "и"}, {"j", "й"}, {"k", "к"}, {"l", "л"},
+	{"m", "м"}, {"n", "н"}, {"o", "о"}, {"p", "п"}, {"q", "к"}, {"r", "р"},
+	{"s", "с"}, {"t", "т"}, {"u", "у"}, {"v", "в"}, {"w", "в"}, {"x", "кс"},
+	{"y", "и"}, {"z", "з"},
+}
+
+// latinEndings map word endings to Cyrillic before the letters are mapped.
+var latinEndings = []struct{ latin, cyrillic string }{
+	{"skiy", "ский"}, {"skij", "ский"}, {"sky", "ский"}, {"ski", "ский"},
+	{"iy", "ий"}, {"ij", "ий"}, {"yy", "ый"},
+	{"ay", "ай"}, {"ey", "ей"}, {"oy", "ой"}, {"uy", "уй"},
+}
+
+// Normalize folds ё into е, as the index does.
+func Normalize(s string) string {
+	return strings.NewReplacer("ё", "е", "Ё", "Е").Replace(s)
+}
+
+// HasCyrillic reports whether s contains a Cyrillic letter.
+func HasCyrillic(s string) bool {
+	return strings.IndexFunc(s, isCyrillic) >= 0
+}
+
+// HasLatin reports whether s contains a Latin letter.
+func HasLatin(s string) bool {
+	return strings.IndexFunc(s, isLatin) >= 0
+}
+
+// Variants returns the spellings of s in the other script: the GOST, BGN and
+// common BGN romanizations of its Cyrillic words and the Cyrillic reading of
+// its Latin words. The variants are lowercase, distinct and differ from s.
+func Variants(s string) []string {
+	lower := strings.ToLower(s)
+	var variants []string
+	add := func(v string) {
+		if v != lower && v != Normalize(lower) && !slices.Contains(variants, v) {
+			variants = append(variants, v)
+		}
+	}
+	if HasCyrillic(lower) {
+		for _, scheme := range []Scheme{GOST, BGN, CommonBGN} {
+			add(ToLatin(lower, scheme))
+		}
+	}
+	if HasLatin(lower) {
+		add(ToCyrillic(lower))
+	}
+	return variants
+}
+
+// ToLatin romanizes the Cyrillic words of s with scheme and leaves the rest
+// as is.
+func ToLatin(s string, scheme Scheme) string {
+	return mapWords(strings.ToLower(s), func(word string) string {
+		if !HasCyrillic(word) {
+			return word
+		}
+		var tail string
+		for ending, latin := range scheme.endings {
+			if base, ok := strings.CutSuffix(word, ending); ok && base != "" {
+				word, tail = base, latin
+				break
+			}
+		}
+
+		var b strings.Builder
+		prev := rune(0)
+		for _, r := range word {
+			latin, ok := scheme.letters[r]
+			if iotated, isIotated := scheme.iotated[r]; isIotated && (prev == 0 || isVowel(prev) || prev == 'ъ' || prev == 'ь') {
+				latin, ok = iotated, true
+			}
+			if ok {
+				b.WriteString(latin)
+			} else {
+				b.WriteRune(r)
+			}
+			prev = r
+		}
+		return b.String() + tail
+	})
+}
+
+// ToCyrillic reads the Latin words of s as romanized Russian and leaves the
+// rest as is. The result is a best guess: romanization loses information, so
+// callers should match it fuzzily.
+func ToCyrillic(s string) string {
+	return mapWords(strings.ToLower(s), func(word string) string {
+		if !HasLatin(word) {
+			return word
+		}
+		var tail string
+		for _, e := range latinEndings {
+			if base, ok := strings.CutSuffix(word, e.latin); ok && base != "" {
+				word, tail = base, e.cyrillic
+				break
+			}
+		}
+
+		var b strings.Builder
+		for i := 0; i < len(word); {
+			if i == 0 && word[0] == 'e' {
+				b.WriteString("э")
+				i++
+				continue
+			}
+			matched := false
+			for _, m := range fromLatin {
+				if strings.HasPrefix(word[i:], m.latin) {
+					b.WriteString(m.cyrillic)
+					i += len(m.latin)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				r := []rune(word[i:])[0]
+				b.WriteRune(r)
+				i += len(string(r))
+			}
+		}
+		return b.String() + tail
+	})
+}
+
+// mapWords applies f to every run of letters in s.
+func mapWords(s string, f func(string) string) string {
+	var b strings.Builder
+	start := -1
+	for i, r := range s {
+		if unicode.IsLetter(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			b.WriteString(f(s[start:i]))
+			start = -1
+		}
+		b.WriteRune(r)
+	}
+	if start >= 0 {
+		b.WriteString(f(s[start:]))
+	}
+	return b.String()
+}
+
+func isCyrillic(r rune) bool {
+	return unicode.Is(unicode.Cyrillic, r)
+}
+
+func isLatin(r rune) bool {
+	return r < unicode.MaxASCII && unicode.IsLetter(r)
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune("аеёиоуыэюя", r)
+}
@@ -18,10 +18,15 @@ from src.utils.jwt_manager import jwt_manager
 router = APIRouter(tags=['auth'])
 
 
-def create_full_tokens(user_id: str) -> tuple[str, str]:
+def create_access_token(user: User) -> str:
+    """Создание access токена пользователя"""
+    return jwt_manager.create_access_token(str(user.id), user.age_rating, user.is_subscriber)
+
+
+def create_full_tokens(user: User) -> tuple[str, str]:
     """Создание access и refresh токенов"""
-    access_token = jwt_manager.create_access_token(user_id)
-    refresh_token = jwt_manager.create_refresh_token(user_id)
+    access_token = create_access_token(user)
+    refresh_token = jwt_manager.create_refresh_token(str(user.id))
     return access_token, refresh_token
 
 
@@ -48,7 +53,7 @@ async def signup(
         db.add(user)
         await db.commit()
         await db.refresh(user)
-        access_token, refresh_token = create_full_tokens(str(user.id))
+        access_token, refresh_token = create_full_tokens(user)
         await sessions.create_session(
             user_id=user.id,
             user_agent=request.headers.get('User-Agent'),
@@ -86,7 +91,7 @@ async def signin(
             )
         if not hash_password.check_password(user.password_hash, data.password):
             raise HTTPException(status_code=HTTPStatus.FORBIDDEN, detail='password is not correct')
-        access_token, refresh_token = create_full_tokens(str(user.id))
+        access_token, refresh_token = create_full_tokens(user)
         await sessions.create_session(
             user_id=user.id,
             user_agent=request.headers.get('User-Agent'),
@@ -124,8 +129,13 @@ async def refresh(
     user_id: str = Depends(get_current_user_refresh),
     cache_adapter: redis.RedisAdapter = Depends(redis.get_redis_adapter),
     credentials: HTTPAuthorizationCredentials = Depends(security),
+    db: AsyncSession = Depends(get_async_session),
 ) -> dict[str, Any]:
     """Refresh token"""
+    result = await db.execute(select(User).where(User.id == user_id))
+    user = result.scalar_one_or_none()
+    if user is None:
+        raise HTTPException(status_code=HTTPStatus.UNAUTHORIZED, detail='User not found')
     # Добавляем старый refresh токен в blacklist
     old_token = credentials.credentials
     old_payload = jwt_manager.get_token_payload(old_token)
@@ -133,5 +143,5 @@ async def refresh(
     await cache_adapter.put_object_to_cache(
         f'blacklist:{old_jti}', 'refreshed', ex=settings.security.jwt_refresh_token_expires
     )
-    access_token = jwt_manager.create_access_token(user_id)
+    access_token = create_access_token(user)
     return {'access_token': access_token}
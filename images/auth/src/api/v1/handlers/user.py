@@ -31,6 +31,8 @@ async def get_user_info(
         'user_created_at': user.created_at,
         'user_email': user.email,
         'is_email_confirmed': user.is_email_confirmed,
+        'age_rating': user.age_rating,
+        'is_subscriber': user.is_subscriber,
     }
 
 
@@ -0,0 +1,36 @@
+"""user audience
+
+Revision ID: 8c1f3a2d4b7e
+Revises: 5259bb87f019
+Create Date: 2026-10-19 12:00:00.000000
+
+"""
+
+from typing import Sequence, Union
+
+import sqlalchemy as sa
+from alembic import op
+
+# revision identifiers, used by Alembic.
+revision: str = '8c1f3a2d4b7e'
+down_revision: Union[str, Sequence[str], None] = '5259bb87f019'
+branch_labels: Union[str, Sequence[str], None] = None
+depends_on: Union[str, Sequence[str], None] = None
+
+
+def upgrade() -> None:
+    """Upgrade schema."""
+    op.add_column(
+        'users',
+        sa.Column('age_rating', sa.String(length=8), server_default='NC-17', nullable=False),
+    )
+    op.add_column(
+        'users',
+        sa.Column('is_subscriber', sa.Boolean(), server_default=sa.false(), nullable=False),
+    )
+
+
+def downgrade() -> None:
+    """Downgrade schema."""
+    op.drop_column('users', 'is_subscriber')
+    op.drop_column('users', 'age_rating')
@@ -1,7 +1,7 @@
 import uuid
 from datetime import datetime, timezone
 
-from sqlalchemy import Boolean, Column, DateTime, String
+from sqlalchemy import Boolean, Column, DateTime, String, false
 from sqlalchemy.dialects.postgresql import UUID
 from sqlalchemy.orm import relationship
 
@@ -29,6 +29,11 @@ class User(Base):
     is_active = Column(Boolean())
     is_email_confirmed = Column(Boolean())
 
+    # Аудитория пользователя: старший доступный возрастной рейтинг и подписка.
+    # Передаются в access токене для рекомендаций async-api.
+    age_rating = Column(String(8), default='NC-17', server_default='NC-17', nullable=False)
+    is_subscriber = Column(Boolean(), default=False, server_default=false(), nullable=False)
+
     created_at = Column(
         DateTime(timezone=True),
         default=datetime.now(timezone.utc),
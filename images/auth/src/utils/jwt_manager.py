@@ -38,12 +38,14 @@ class JWTManager:
         encoded_jwt = jwt.encode(to_encode, self.secret_key, algorithm=self.algorithm)
         return encoded_jwt
 
-    def create_access_token(self, subject: str) -> str:
-        """Создание access токена"""
+    def create_access_token(self, subject: str, age_rating: str, subscriber: bool) -> str:
+        """Создание access токена с аудиторией пользователя"""
         return self.create_token(
             subject=subject,
             expires_delta=timedelta(minutes=settings.security.jwt_access_token_expires),
             token_type='access',
+            age_rating=age_rating,
+            subscriber=subscriber,
         )
 
     def create_refresh_token(self, subject: str) -> str: